@@ -0,0 +1,104 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"math"
+	"time"
+)
+
+// Jitter is a strategy used by [Backoff] to randomize retry delays.
+//
+// See "Exponential Backoff And Jitter" by Marc Brooker,
+// https://aws.amazon.com/blogs/architecture/exponential-backoff-and-jitter/
+type Jitter int
+
+const (
+	// FullJitter delays are uniformly distributed in [0, min(max, base * 2^attempt)).
+	FullJitter Jitter = iota
+	// EqualJitter delays are uniformly distributed in [d/2, d), where d = min(max, base * 2^attempt).
+	EqualJitter
+	// DecorrelatedJitter delays are uniformly distributed in [base, prev * 3), capped at max.
+	DecorrelatedJitter
+)
+
+// A Backoff generates jittered exponential backoff delays.
+//
+// Backoff is not safe for concurrent use; use a separate Backoff for each sequence of retries.
+type Backoff struct {
+	r       *Rand
+	jitter  Jitter
+	base    time.Duration
+	max     time.Duration
+	attempt int
+	prev    time.Duration
+}
+
+// NewBackoff returns a Backoff that uses the jitter strategy to generate delays starting from base,
+// never exceeding max. It panics if base <= 0, max < base or jitter is unknown.
+//
+// When r is nil, Backoff uses non-deterministic goroutine-local pseudo-random data source.
+func NewBackoff(r *Rand, jitter Jitter, base time.Duration, max time.Duration) *Backoff {
+	if base <= 0 || max < base || jitter < FullJitter || jitter > DecorrelatedJitter {
+		panic("invalid argument to NewBackoff")
+	}
+	return &Backoff{
+		r:      r,
+		jitter: jitter,
+		base:   base,
+		max:    max,
+		prev:   base,
+	}
+}
+
+// Next returns the delay to wait before the next retry attempt.
+func (b *Backoff) Next() time.Duration {
+	var d time.Duration
+	switch b.jitter {
+	case FullJitter:
+		d = b.duration(0, b.ceil())
+	case EqualJitter:
+		c := b.ceil()
+		d = b.duration(c/2, c)
+	case DecorrelatedJitter:
+		hi := time.Duration(math.MaxInt64)
+		if b.prev <= hi/3 {
+			hi = b.prev * 3
+		}
+		d = b.duration(b.base, hi)
+		if d > b.max {
+			d = b.max
+		}
+		b.prev = d
+	}
+	b.attempt++
+	return d
+}
+
+// Reset restores the Backoff to its initial state, as returned by [NewBackoff].
+func (b *Backoff) Reset() {
+	b.attempt = 0
+	b.prev = b.base
+}
+
+// ceil returns min(max, base * 2^attempt) without overflowing.
+func (b *Backoff) ceil() time.Duration {
+	if b.attempt >= 63 || b.base > b.max>>b.attempt {
+		return b.max
+	}
+	return b.base << b.attempt
+}
+
+func (b *Backoff) duration(min time.Duration, max time.Duration) time.Duration {
+	if max <= min {
+		return min
+	}
+	if b.r == nil {
+		return Duration(min, max)
+	}
+	return b.r.Duration(min, max)
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		var r *rand.Rand
+		if rapid.Bool().Draw(t, "seeded").(bool) {
+			r = rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		}
+		jitter := rand.Jitter(rapid.IntRange(int(rand.FullJitter), int(rand.DecorrelatedJitter)).Draw(t, "jitter").(int))
+		base := time.Duration(rapid.Int64Range(1, int64(time.Hour)).Draw(t, "base").(int64))
+		max := base + time.Duration(rapid.Int64Range(0, int64(time.Hour)).Draw(t, "extra").(int64))
+		b := rand.NewBackoff(r, jitter, base, max)
+		for i := 0; i < 100; i++ {
+			d := b.Next()
+			if d < 0 || d > max {
+				t.Fatalf("attempt %v: got %v outside of [0, %v]", i, d, max)
+			}
+			if jitter == rand.DecorrelatedJitter && d < base {
+				t.Fatalf("attempt %v: got %v less than base %v", i, d, base)
+			}
+		}
+	})
+}
+
+func TestBackoff_Reset(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		jitter := rand.Jitter(rapid.IntRange(int(rand.FullJitter), int(rand.DecorrelatedJitter)).Draw(t, "jitter").(int))
+		b := rand.NewBackoff(r, jitter, time.Millisecond, time.Minute)
+		var d1 []time.Duration
+		for i := 0; i < 20; i++ {
+			d1 = append(d1, b.Next())
+		}
+		r.Seed(s)
+		b.Reset()
+		for i, d := range d1 {
+			if d2 := b.Next(); d2 != d {
+				t.Fatalf("attempt %v: got %v instead of %v after reset", i, d2, d)
+			}
+		}
+	})
+}
+
+func TestBackoff_FullJitterGrowth(t *testing.T) {
+	r := rand.New(1)
+	b := rand.NewBackoff(r, rand.FullJitter, time.Millisecond, time.Hour)
+	for i := 0; i < 10; i++ {
+		ceil := time.Millisecond << i
+		if d := b.Next(); d >= ceil {
+			t.Fatalf("attempt %v: got %v outside of [0, %v)", i, d, ceil)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// BigIntn returns, as a *big.Int, a uniformly distributed non-negative pseudo-random number
+// in the half-open interval [0, n). It panics if n <= 0.
+func (r *Rand) BigIntn(n *big.Int) *big.Int {
+	if n.Sign() <= 0 {
+		panic("invalid argument to BigIntn")
+	}
+	return bigIntn(r.next64, n)
+}
+
+// BigFloat returns, as a *big.Float with precision prec, a uniformly distributed
+// pseudo-random number in the half-open interval [0.0, 1.0). It panics if prec == 0.
+func (r *Rand) BigFloat(prec uint) *big.Float {
+	if prec == 0 {
+		panic("invalid argument to BigFloat")
+	}
+	return bigFloat(r.next64, prec)
+}
+
+// BigIntn returns, as a *big.Int, a uniformly distributed non-negative pseudo-random number
+// in the half-open interval [0, n). It panics if n <= 0.
+func BigIntn(n *big.Int) *big.Int {
+	if n.Sign() <= 0 {
+		panic("invalid argument to BigIntn")
+	}
+	return bigIntn(rand64, n)
+}
+
+// BigFloat returns, as a *big.Float with precision prec, a uniformly distributed
+// pseudo-random number in the half-open interval [0.0, 1.0). It panics if prec == 0.
+func BigFloat(prec uint) *big.Float {
+	if prec == 0 {
+		panic("invalid argument to BigFloat")
+	}
+	return bigFloat(rand64, prec)
+}
+
+func bigIntn(next func() uint64, n *big.Int) *big.Int {
+	// multiword version of Uint64n: n*x for k-word n and k-word random fraction x, with the
+	// fractional part corrected by one more word; result is unbiased with probability 1 - 2^-64
+	nw := bigToWords(n)
+	k := len(nw)
+	if k == 1 {
+		res, frac := bits.Mul64(nw[0], next())
+		if nw[0] <= math.MaxUint32 {
+			return new(big.Int).SetUint64(res)
+		}
+		hi, _ := bits.Mul64(nw[0], next())
+		_, carry := bits.Add64(frac, hi, 0)
+		return new(big.Int).SetUint64(res + carry)
+	}
+
+	x := make([]uint64, k)
+	for i := k - 1; i >= 0; i-- {
+		x[i] = next() // most significant word of the fraction comes first, as in Uint64n
+	}
+	p := mulWords(nw, x)
+	q := mulWords(nw, []uint64{next()})
+
+	var carry uint64
+	for i := 0; i < k; i++ {
+		p[i], carry = bits.Add64(p[i], q[i+1], carry)
+	}
+	for i := k; i < 2*k; i++ {
+		p[i], carry = bits.Add64(p[i], 0, carry)
+	}
+	return wordsToBig(p[k:])
+}
+
+func bigFloat(next func() uint64, prec uint) *big.Float {
+	// see Float64: prec random bits scaled by 2^-prec are exactly representable
+	k := (prec + 63) / 64
+	m := make([]uint64, k)
+	for i := range m {
+		m[i] = next()
+	}
+	if rem := prec % 64; rem != 0 {
+		m[k-1] &= 1<<rem - 1
+	}
+	f := new(big.Float).SetPrec(prec).SetInt(wordsToBig(m))
+	return f.SetMantExp(f, -int(prec))
+}
+
+// bigToWords returns little-endian 64-bit words of non-negative x, independent of the platform word size.
+func bigToWords(x *big.Int) []uint64 {
+	k := (x.BitLen() + 63) / 64
+	buf := x.FillBytes(make([]byte, 8*k))
+	w := make([]uint64, k)
+	for i := range w {
+		w[i] = binary.BigEndian.Uint64(buf[8*(k-1-i):])
+	}
+	return w
+}
+
+func wordsToBig(w []uint64) *big.Int {
+	buf := make([]byte, 8*len(w))
+	for i, u := range w {
+		binary.BigEndian.PutUint64(buf[8*(len(w)-1-i):], u)
+	}
+	return new(big.Int).SetBytes(buf)
+}
+
+// mulWords returns the full little-endian product of a and b.
+func mulWords(a []uint64, b []uint64) []uint64 {
+	z := make([]uint64, len(a)+len(b))
+	for j, v := range b {
+		var c uint64
+		for i, u := range a {
+			hi, lo := bits.Mul64(u, v)
+			var cc uint64
+			lo, cc = bits.Add64(lo, z[i+j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			z[i+j] = lo
+			c = hi
+		}
+		z[len(a)+j] = c
+	}
+	return z
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math/big"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func BenchmarkRand_BigIntn(b *testing.B) {
+	r := rand.New(1)
+	n := new(big.Int).Lsh(big.NewInt(small), 200)
+	for i := 0; i < b.N; i++ {
+		r.BigIntn(n)
+	}
+}
+
+func TestRand_BigIntn(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		b := rapid.SliceOfN(rapid.Byte(), 1, 64).Draw(t, "n").([]byte)
+		n := new(big.Int).SetBytes(b)
+		if n.Sign() == 0 {
+			n.SetInt64(1)
+		}
+		v := r.BigIntn(n)
+		if v.Sign() < 0 || v.Cmp(n) >= 0 {
+			t.Fatalf("got %v outside of [0, %v)", v, n)
+		}
+	})
+}
+
+func TestRand_BigIntn_Uint64n(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		n := rapid.Uint64Min(1).Draw(t, "n").(uint64)
+		v1 := r.BigIntn(new(big.Int).SetUint64(n))
+		r.Seed(s)
+		v2 := r.Uint64n(n)
+		if !v1.IsUint64() || v1.Uint64() != v2 {
+			t.Fatalf("got %v instead of %v", v1, v2)
+		}
+	})
+}
+
+func TestRand_BigIntn_Uint128n(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		hi := rapid.Uint64().Draw(t, "hi").(uint64)
+		lo := rapid.Uint64().Draw(t, "lo").(uint64)
+		if hi == 0 && lo == 0 {
+			lo = 1
+		}
+		n := new(big.Int).Lsh(new(big.Int).SetUint64(hi), 64)
+		n.Or(n, new(big.Int).SetUint64(lo))
+		v1 := r.BigIntn(n)
+		r.Seed(s)
+		vh, vl := r.Uint128n(hi, lo)
+		v2 := new(big.Int).Lsh(new(big.Int).SetUint64(vh), 64)
+		v2.Or(v2, new(big.Int).SetUint64(vl))
+		if v1.Cmp(v2) != 0 {
+			t.Fatalf("got %v instead of %v", v1, v2)
+		}
+	})
+}
+
+func TestRand_BigFloat(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		prec := rapid.UintRange(1, 1000).Draw(t, "prec").(uint)
+		f := r.BigFloat(prec)
+		if f.Prec() != prec {
+			t.Fatalf("got precision %v instead of %v", f.Prec(), prec)
+		}
+		if f.Sign() < 0 || f.Cmp(big.NewFloat(1)) >= 0 {
+			t.Fatalf("got %v outside of [0, 1)", f)
+		}
+	})
+}
+
+func TestBigIntn(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		b := rapid.SliceOfN(rapid.Byte(), 1, 64).Draw(t, "n").([]byte)
+		n := new(big.Int).SetBytes(b)
+		if n.Sign() == 0 {
+			n.SetInt64(1)
+		}
+		v := rand.BigIntn(n)
+		if v.Sign() < 0 || v.Cmp(n) >= 0 {
+			t.Fatalf("got %v outside of [0, %v)", v, n)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math/bits"
+
+const bijectionRounds = 8
+
+// A Bijection is a pseudo-random permutation of the integers in the half-open interval [0, n),
+// computed on demand in O(1) time and memory per element. It allows to iterate over
+// a huge range in pseudo-random order without materializing the permutation like [Rand.Perm] does.
+//
+// Bijection is a format-preserving balanced Feistel network with cycle-walking.
+// Unlike [Rand.Perm], permutations returned by [NewBijection] are not uniformly distributed
+// over all n! possible permutations, but are statistically indistinguishable from random
+// for practical purposes.
+//
+// Bijection is safe for concurrent use by multiple goroutines.
+type Bijection struct {
+	n    uint64
+	half uint
+	mask uint64
+	keys [bijectionRounds]uint64
+}
+
+// NewBijection returns a pseudo-random permutation of the integers in the half-open interval [0, n),
+// with round keys drawn from r. It panics if n == 0.
+//
+// When r is nil, NewBijection uses non-deterministic goroutine-local pseudo-random data source.
+func NewBijection(r *Rand, n uint64) *Bijection {
+	if n == 0 {
+		panic("invalid argument to NewBijection")
+	}
+	b := &Bijection{n: n}
+	b.half = uint(bits.Len64(n-1)+1) / 2
+	if b.half == 0 {
+		b.half = 1
+	}
+	b.mask = 1<<b.half - 1
+	for i := range b.keys {
+		if r == nil {
+			b.keys[i] = Uint64()
+		} else {
+			b.keys[i] = r.Uint64()
+		}
+	}
+	return b
+}
+
+// Len returns the number of elements in the permutation.
+func (b *Bijection) Len() uint64 {
+	return b.n
+}
+
+// At returns the element at position i of the permutation. It panics if i >= Len().
+func (b *Bijection) At(i uint64) uint64 {
+	if i >= b.n {
+		panic("invalid argument to At")
+	}
+	// cycle-walking: Feistel network permutes [0, 2^(2*half)), which contains less than 4n elements
+	for {
+		i = b.encrypt(i)
+		if i < b.n {
+			return i
+		}
+	}
+}
+
+// Inverse returns the position of element j in the permutation, so that At(Inverse(j)) == j.
+// It panics if j >= Len().
+func (b *Bijection) Inverse(j uint64) uint64 {
+	if j >= b.n {
+		panic("invalid argument to Inverse")
+	}
+	for {
+		j = b.decrypt(j)
+		if j < b.n {
+			return j
+		}
+	}
+}
+
+func (b *Bijection) encrypt(x uint64) uint64 {
+	l, r := x>>b.half, x&b.mask
+	for _, k := range b.keys {
+		l, r = r, l^(feistelRound(k, r)&b.mask)
+	}
+	return l<<b.half | r
+}
+
+func (b *Bijection) decrypt(x uint64) uint64 {
+	l, r := x>>b.half, x&b.mask
+	for i := len(b.keys) - 1; i >= 0; i-- {
+		l, r = r^(feistelRound(b.keys[i], l)&b.mask), l
+	}
+	return l<<b.half | r
+}
+
+func feistelRound(k uint64, x uint64) uint64 {
+	// splitmix64 finalizer
+	z := x + k
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
@@ -0,0 +1,79 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func BenchmarkBijection_At(b *testing.B) {
+	p := rand.NewBijection(rand.New(1), 1e12)
+	var s uint64
+	for i := 0; i < b.N; i++ {
+		s = p.At(uint64(i))
+	}
+	sinkUint64 = s
+}
+
+func TestBijection_Small(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		n := rapid.Uint64Range(1, small).Draw(t, "n").(uint64)
+		p := rand.NewBijection(rand.New(s), n)
+		seen := make([]bool, n)
+		for i := uint64(0); i < n; i++ {
+			j := p.At(i)
+			if j >= n {
+				t.Fatalf("got %v outside of [0, %v)", j, n)
+			}
+			if seen[j] {
+				t.Fatalf("got %v more than once", j)
+			}
+			seen[j] = true
+			if k := p.Inverse(j); k != i {
+				t.Fatalf("got inverse %v instead of %v", k, i)
+			}
+		}
+	})
+}
+
+func TestBijection_Large(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		n := rapid.Uint64Range(small, math.MaxUint64).Draw(t, "n").(uint64)
+		i := rapid.Uint64Range(0, n-1).Draw(t, "i").(uint64)
+		p := rand.NewBijection(rand.New(s), n)
+		j := p.At(i)
+		if j >= n {
+			t.Fatalf("got %v outside of [0, %v)", j, n)
+		}
+		if k := p.Inverse(j); k != i {
+			t.Fatalf("got inverse %v instead of %v", k, i)
+		}
+	})
+}
+
+func TestBijection_Spread(t *testing.T) {
+	// every element should be equally likely to land in the first half of the permutation
+	const n, trials = 16, 20000
+	r := rand.New(1)
+	var counts [n]int
+	for i := 0; i < trials; i++ {
+		p := rand.NewBijection(r, n)
+		for j := uint64(0); j < n/2; j++ {
+			counts[p.At(j)]++
+		}
+	}
+	for j, c := range counts {
+		if math.Abs(float64(c)-trials/2) > 5*math.Sqrt(trials/4) {
+			t.Errorf("element %v in first half %v times out of %v", j, c, trials)
+		}
+	}
+}
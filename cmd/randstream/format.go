@@ -0,0 +1,101 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const hexLineBytes = 32
+
+var formats = []string{"raw", "hex", "decimal", "float", "npy"}
+
+// write writes n bytes read from r (or everything, when n is 0) to w in the specified format.
+// Text formats other than hex, and npy, interpret every 8 bytes as a little-endian uint64.
+func write(w io.Writer, r io.Reader, format string, n int64) error {
+	if n < 0 {
+		return fmt.Errorf("invalid byte count: %v", n)
+	}
+	if format != "raw" && format != "hex" && n%8 != 0 {
+		return fmt.Errorf("byte count for %q format must be a multiple of 8, got %v", format, n)
+	}
+	if n > 0 {
+		r = io.LimitReader(r, n)
+	}
+	bw := bufio.NewWriter(w)
+	var err error
+	switch format {
+	case "raw":
+		_, err = io.Copy(bw, r)
+	case "hex":
+		err = writeText(bw, r, hexLineBytes, func(b []byte) string { return hex.EncodeToString(b) })
+	case "decimal":
+		err = writeText(bw, r, 8, func(b []byte) string { return strconv.FormatUint(binary.LittleEndian.Uint64(b), 10) })
+	case "float":
+		err = writeText(bw, r, 8, func(b []byte) string {
+			return strconv.FormatFloat(float64(binary.LittleEndian.Uint64(b)>>11)*0x1.0p-53, 'g', -1, 64)
+		})
+	case "npy":
+		if n == 0 {
+			return fmt.Errorf("byte count is required for %q format", format)
+		}
+		if _, err = bw.Write(npyHeader(n / 8)); err == nil {
+			_, err = io.Copy(bw, r)
+		}
+	default:
+		return fmt.Errorf("unknown format: %q", format)
+	}
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// writeText writes every size bytes read from r as a separate line; last line can be shorter.
+func writeText(w *bufio.Writer, r io.Reader, size int, line func([]byte) string) error {
+	buf := make([]byte, size)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if _, err := w.WriteString(line(buf[:n]) + "\n"); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// npyHeader returns the header of a NumPy .npy file (format version 1.0) with a one-dimensional
+// array of count little-endian uint64 values, see https://numpy.org/doc/stable/reference/generated/numpy.lib.format.html.
+func npyHeader(count int64) []byte {
+	const (
+		magic = "\x93NUMPY\x01\x00"
+		align = 64
+	)
+	dict := fmt.Sprintf("{'descr': '<u8', 'fortran_order': False, 'shape': (%d,), }", count)
+	// header, including magic, 2-byte length and terminating newline, is padded with spaces to a multiple of align
+	pad := align - (len(magic)+2+len(dict)+1)%align
+	if pad == align {
+		pad = 0
+	}
+	dict += strings.Repeat(" ", pad) + "\n"
+	h := make([]byte, len(magic)+2, len(magic)+2+len(dict))
+	copy(h, magic)
+	binary.LittleEndian.PutUint16(h[len(magic):], uint16(len(dict)))
+	return append(h, dict...)
+}
@@ -0,0 +1,69 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Command randstream writes a stream of pseudo-random data to standard output, for feeding
+// to external statistical test suites like PractRand, TestU01 or dieharder, or to other tools.
+//
+// Usage:
+//
+//	randstream [flags]
+//
+// Stream is fully determined by the flags; when -seed is not specified, a random seed
+// is used and printed to standard error, so that the stream can be reproduced later.
+// Without -n, the stream is infinite. For example, to test with PractRand:
+//
+//	randstream -gen rand | RNG_test stdin64
+package main
+
+import (
+	"flag"
+	"fmt"
+	"hash/maphash"
+	"log"
+	"os"
+	"pgregory.net/rand/internal/sources"
+	"strings"
+)
+
+func run(gen string, transform string, shuffle string, format string, seed uint64, n int64) error {
+	g, err := newSource(gen, transform, seed)
+	if err != nil {
+		return err
+	}
+	b, err := newShuffle(shuffle)
+	if err != nil {
+		return err
+	}
+	return write(os.Stdout, newStream(g, b), format, n)
+}
+
+func main() {
+	var (
+		gen       = flag.String("gen", "rand", "RNG to use ("+strings.Join(sources.Names, "/")+")")
+		transform = flag.String("transform", "none", "transform to use ("+strings.Join(transforms, "/")+")")
+		shuffle   = flag.String("shuffle", "none", "shuffle algorithm to use ("+strings.Join(shuffles, "/")+")")
+		format    = flag.String("format", "raw", "output format ("+strings.Join(formats, "/")+")")
+		seed      = flag.Uint64("seed", 0, "RNG seed (random if not specified)")
+		n         = flag.Int64("n", 0, "number of bytes of RNG output to write (0 for infinite stream)")
+	)
+	flag.Parse()
+
+	seedSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			seedSet = true
+		}
+	})
+	if !seedSet {
+		*seed = new(maphash.Hash).Sum64()
+		fmt.Fprintf(os.Stderr, "randstream: using -seed %v\n", *seed)
+	}
+
+	err := run(*gen, *transform, *shuffle, *format, *seed, *n)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"pgregory.net/rand"
+	"pgregory.net/rand/internal/sources"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func output(t *testing.T, gen string, format string, seed uint64, n int64) []byte {
+	t.Helper()
+	g, err := newSource(gen, "none", seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := write(&buf, newStream(g, nil), format, n); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestFormats(t *testing.T) {
+	const seed, count = 1, 100
+	r := rand.New(seed)
+	want := make([]uint64, count)
+	for i := range want {
+		want[i] = r.Uint64()
+	}
+
+	raw := output(t, "rand", "raw", seed, 8*count)
+	for i, u := range want {
+		if got := binary.LittleEndian.Uint64(raw[8*i:]); got != u {
+			t.Fatalf("raw: got %v instead of %v at %v", got, u, i)
+		}
+	}
+
+	if got := string(output(t, "rand", "hex", seed, 8*count)); strings.ReplaceAll(got, "\n", "") != fmt.Sprintf("%x", raw) {
+		t.Fatalf("hex: got %q", got)
+	}
+
+	lines := strings.Fields(string(output(t, "rand", "decimal", seed, 8*count)))
+	for i, u := range want {
+		if lines[i] != strconv.FormatUint(u, 10) {
+			t.Fatalf("decimal: got %v instead of %v at %v", lines[i], u, i)
+		}
+	}
+
+	lines = strings.Fields(string(output(t, "rand", "float", seed, 8*count)))
+	for i, u := range want {
+		if f, err := strconv.ParseFloat(lines[i], 64); err != nil || f != float64(u>>11)*0x1.0p-53 {
+			t.Fatalf("float: got %v at %v", lines[i], i)
+		}
+	}
+
+	npy := output(t, "rand", "npy", seed, 8*count)
+	header := npyHeader(count)
+	if len(header)%64 != 0 || !bytes.HasPrefix(npy, header) || !bytes.Equal(npy[len(header):], raw) {
+		t.Fatalf("npy: got header %q", npy[:len(header)])
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	for _, gen := range sources.Names {
+		for _, transform := range transforms {
+			g, err := newSource(gen, transform, 1)
+			if err != nil {
+				t.Fatalf("%v/%v: %v", gen, transform, err)
+			}
+			g()
+		}
+	}
+	for _, shuffle := range shuffles {
+		if _, err := newShuffle(shuffle); err != nil {
+			t.Fatalf("%v: %v", shuffle, err)
+		}
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	if _, err := newSource("nope", "none", 1); err == nil {
+		t.Error("unknown generator accepted")
+	}
+	if _, err := newSource("rand", "nope", 1); err == nil {
+		t.Error("unknown transform accepted")
+	}
+	if _, err := newShuffle("nope"); err == nil {
+		t.Error("unknown shuffle accepted")
+	}
+	g, _ := newSource("rand", "none", 1)
+	for _, c := range []struct {
+		format string
+		n      int64
+	}{{"nope", 8}, {"decimal", 7}, {"npy", 0}, {"raw", -1}} {
+		if err := write(&bytes.Buffer{}, newStream(g, nil), c.format, c.n); err == nil {
+			t.Errorf("format %q with %v bytes accepted", c.format, c.n)
+		}
+	}
+}
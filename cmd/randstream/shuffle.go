@@ -0,0 +1,142 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+const (
+	chunkSizeBits  = 1 << 16
+	chunkSizeBytes = chunkSizeBits / 8
+	numChunks      = 1024
+	bufSizeBits    = numChunks * chunkSizeBits
+	bufSizeBytes   = bufSizeBits / 8
+	bufSizeWords   = bufSizeBytes / 8
+)
+
+var shuffles = []string{"none", "mod", "fp", "lfp", "lemire"}
+
+func uint16nModulo(g func() uint64, n uint16) uint16 {
+	return uint16(g()) % n // biased
+}
+
+func uint16nFixedPoint(g func() uint64, n uint16) uint16 {
+	v := uint16(g())
+	x := uint32(n) * uint32(v)
+	return uint16(x >> 16) // biased
+}
+
+func uint16nLongFixedPoint(g func() uint64, n uint16) uint16 {
+	res, _ := bits.Mul32(uint32(n), uint32(g()))
+	return uint16(res) // biased with probability 2^-16
+}
+
+func uint16nLemire(g func() uint64, n uint16) uint16 {
+	v := uint16(g())
+	prod := uint32(v) * uint32(n)
+	low := uint16(prod)
+	if low < n {
+		thresh := -n % n
+		for low < thresh {
+			v = uint16(g())
+			prod = uint32(v) * uint32(n)
+			low = uint16(prod)
+		}
+	}
+	return uint16(prod >> 16) // unbiased
+}
+
+func shuffleBits(buf []byte, g func() uint64, b func(func() uint64, uint16) uint16) {
+	for i := math.MaxUint16 - 1; i > 0; i-- {
+		j := int(b(g, uint16(i+1)))
+		bi := getBit(buf, i)
+		bj := getBit(buf, j)
+		setBit(buf, i, bj)
+		setBit(buf, j, bi)
+	}
+}
+
+func getBit(buf []byte, i int) bool {
+	return buf[i/8]&(1<<(i%8)) > 0
+}
+
+func setBit(buf []byte, i int, b bool) {
+	if b {
+		buf[i/8] |= 1 << (i % 8)
+	} else {
+		buf[i/8] &= ^(1 << (i % 8))
+	}
+}
+
+func newShuffle(shuffle string) (func(func() uint64, uint16) uint16, error) {
+	switch shuffle {
+	case "none":
+		return nil, nil
+	case "mod":
+		return uint16nModulo, nil
+	case "fp":
+		return uint16nFixedPoint, nil
+	case "lfp":
+		return uint16nLongFixedPoint, nil
+	case "lemire":
+		return uint16nLemire, nil
+	default:
+		return nil, fmt.Errorf("unknown shuffle method: %q", shuffle)
+	}
+}
+
+// stream is an infinite io.Reader of generator output. When b is not nil, instead of raw output
+// stream consists of chunks with equal number of zero and one bits, shuffled using b.
+type stream struct {
+	g   func() uint64
+	b   func(func() uint64, uint16) uint16
+	buf []byte
+	pos int
+}
+
+func newStream(g func() uint64, b func(func() uint64, uint16) uint16) *stream {
+	buf := make([]byte, bufSizeBytes)
+	return &stream{g: g, b: b, buf: buf, pos: len(buf)}
+}
+
+func (s *stream) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if s.pos == len(s.buf) {
+			s.fill()
+		}
+		c := copy(p[n:], s.buf[s.pos:])
+		n += c
+		s.pos += c
+	}
+	return n, nil
+}
+
+func (s *stream) fill() {
+	s.pos = 0
+	if s.b == nil {
+		for i := 0; i < bufSizeWords; i++ {
+			binary.LittleEndian.PutUint64(s.buf[i*8:], s.g())
+		}
+		return
+	}
+	for i := 0; i < numChunks; i++ {
+		ch := s.buf[i*chunkSizeBytes : (i+1)*chunkSizeBytes]
+		for j := 0; j < len(ch); j++ {
+			if j < len(ch)/2 {
+				ch[j] = 0xff
+			} else {
+				ch[j] = 0
+			}
+		}
+		shuffleBits(ch, s.g, s.b)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+	"math"
+	"pgregory.net/rand/internal/sources"
+)
+
+const maxInt52 = 1<<52 - 1
+
+var transforms = []string{"none", "f64", "norm", "exp", "8seed"}
+
+type rand64 struct {
+	rng sources.Gen
+}
+
+func (r *rand64) raw() uint64 {
+	return r.rng.Uint64()
+}
+
+func (r *rand64) fromF64() uint64 {
+	return floatToUniform(r.rng.Float64(), r.rng.Float64())
+}
+
+func (r *rand64) fromNorm() uint64 {
+	return floatToUniform(normalCDF(r.rng.NormFloat64()), normalCDF(r.rng.NormFloat64()))
+}
+
+func (r *rand64) fromExp() uint64 {
+	return floatToUniform(expCDF(r.rng.ExpFloat64()), expCDF(r.rng.ExpFloat64()))
+}
+
+func floatToUniform(x float64, y float64) uint64 {
+	return uint64(x*maxInt52)<<52 | uint64(y*maxInt52)
+}
+
+func normalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func expCDF(x float64) float64 {
+	return -math.Expm1(-x)
+}
+
+func newSource(gen string, transform string, seed uint64) (func() uint64, error) {
+	ctor, err := sources.New(gen)
+	if err != nil {
+		return nil, err
+	}
+	rng := func(s uint64) *rand64 { return &rand64{ctor(s)} }
+	switch transform {
+	case "none":
+		return rng(seed).raw, nil
+	case "f64":
+		return rng(seed).fromF64, nil
+	case "norm":
+		return rng(seed).fromNorm, nil
+	case "exp":
+		return rng(seed).fromExp, nil
+	case "8seed":
+		// deliberately weak seeds, independent of seed
+		seeds := [8]uint64{1, 2, 4, 8, 16, 32, 64, 128}
+		gens := [8]*rand64{}
+		for i, s := range seeds {
+			gens[i] = rng(s)
+		}
+		i := 0
+		return func() uint64 {
+			u := gens[i].raw()
+			i = (i + 1) % 8
+			return u
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown transform: %q", transform)
+	}
+}
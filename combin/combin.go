@@ -0,0 +1,246 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package combin implements generation of uniformly distributed pseudo-random
+// combinatorial objects: subsets, compositions, integer and set partitions, Dyck paths and binary trees.
+//
+// All functions accept a [rand.Rand] parameter. When it is nil, functions use
+// non-deterministic goroutine-local pseudo-random data source, and are safe for concurrent use
+// from multiple goroutines.
+package combin
+
+import (
+	"math"
+	"math/bits"
+	"pgregory.net/rand"
+	"pgregory.net/rand/internal/nilsafe"
+	"sort"
+	"sync"
+)
+
+// Subset returns, as a sorted slice of k ints, a uniformly distributed pseudo-random
+// k-element subset of [0, n). It panics if k < 0 or k > n.
+func Subset(r *rand.Rand, n int, k int) []int {
+	if k < 0 || k > n {
+		panic("invalid argument to Subset")
+	}
+	// Floyd's algorithm
+	chosen := make(map[int]struct{}, k)
+	s := make([]int, 0, k)
+	for j := n - k; j < n; j++ {
+		i := nilsafe.Intn(r, j+1)
+		if _, ok := chosen[i]; ok {
+			i = j
+		}
+		chosen[i] = struct{}{}
+		s = append(s, i)
+	}
+	sort.Ints(s)
+	return s
+}
+
+// Composition returns a uniformly distributed pseudo-random composition of n into k positive parts,
+// that is, a slice of k positive ints that sum up to n. It panics if k < 0, k > n, or k == 0 and n != 0.
+func Composition(r *rand.Rand, n int, k int) []int {
+	if k < 0 || k > n || (k == 0 && n != 0) {
+		panic("invalid argument to Composition")
+	}
+	if k == 0 {
+		return []int{}
+	}
+	// stars and bars: k-1 distinct cuts among n-1 gaps between n units
+	cuts := Subset(r, n-1, k-1)
+	parts := make([]int, k)
+	prev := 0
+	for i, c := range cuts {
+		parts[i] = c + 1 - prev
+		prev = c + 1
+	}
+	parts[k-1] = n - prev
+	return parts
+}
+
+var (
+	partitionOnce  sync.Once
+	partitionTable [][]uint64 // partitionTable[m][k-1] is the number of partitions of m into parts <= k, for 1 <= k <= m
+)
+
+// MaxPartition is the largest n for which [Partition] can be used.
+const MaxPartition = 416
+
+func partitionCount(m int, k int) uint64 {
+	if m == 0 {
+		return 1
+	}
+	if k > m {
+		k = m
+	}
+	return partitionTable[m][k-1]
+}
+
+func initPartitionTable() {
+	partitionTable = make([][]uint64, MaxPartition+1)
+	for m := 1; m <= MaxPartition; m++ {
+		partitionTable[m] = make([]uint64, m)
+		for k := 1; k <= m; k++ {
+			c := partitionCount(m-k, k)
+			if k > 1 {
+				c += partitionTable[m][k-2]
+			}
+			partitionTable[m][k-1] = c
+		}
+	}
+}
+
+// Partition returns a uniformly distributed pseudo-random partition of n, as a slice
+// of positive ints in non-increasing order that sum up to n. It panics if n < 0 or n > [MaxPartition].
+func Partition(r *rand.Rand, n int) []int {
+	if n < 0 || n > MaxPartition {
+		panic("invalid argument to Partition")
+	}
+	partitionOnce.Do(initPartitionTable)
+	var parts []int
+	for m, k := n, n; m > 0; {
+		if k > m {
+			k = m
+		}
+		// choose the largest remaining part j <= k with probability proportional
+		// to the number of partitions of m-j into parts <= j
+		x := nilsafe.Uint64n(r, partitionTable[m][k-1])
+		j := k
+		for ; j > 1; j-- {
+			c := partitionCount(m-j, j)
+			if x < c {
+				break
+			}
+			x -= c
+		}
+		parts = append(parts, j)
+		m -= j
+		k = j
+	}
+	return parts
+}
+
+var (
+	setPartitionOnce  sync.Once
+	setPartitionTable [][]uint64 // setPartitionTable[i][b] is the number of ways to place i more elements given b blocks
+)
+
+// MaxSetPartition is the largest n for which [SetPartition] can be used.
+const MaxSetPartition = 25
+
+func initSetPartitionTable() {
+	setPartitionTable = make([][]uint64, MaxSetPartition+1)
+	for i := range setPartitionTable {
+		setPartitionTable[i] = make([]uint64, MaxSetPartition+1-i+1)
+	}
+	for b := range setPartitionTable[0] {
+		setPartitionTable[0][b] = 1
+	}
+	for i := 1; i <= MaxSetPartition; i++ {
+		for b := range setPartitionTable[i] {
+			hi, lo := bits.Mul64(uint64(b), setPartitionTable[i-1][b])
+			if hi != 0 || lo > math.MaxUint64-setPartitionTable[i-1][b+1] {
+				setPartitionTable[i][b] = math.MaxUint64 // unreachable for n <= MaxSetPartition
+			} else {
+				setPartitionTable[i][b] = lo + setPartitionTable[i-1][b+1]
+			}
+		}
+	}
+}
+
+// SetPartition returns a uniformly distributed pseudo-random partition of the set [0, n)
+// as a slice of n block indices, with elements i and j in the same block when
+// s[i] == s[j]. Blocks are numbered in order of their smallest elements, starting from 0.
+// It panics if n < 0 or n > [MaxSetPartition].
+func SetPartition(r *rand.Rand, n int) []int {
+	if n < 0 || n > MaxSetPartition {
+		panic("invalid argument to SetPartition")
+	}
+	setPartitionOnce.Do(initSetPartitionTable)
+	s := make([]int, n)
+	b := 0
+	for i := range s {
+		rest := setPartitionTable[n-i-1]
+		// each existing block has rest[b] completions, a new block has rest[b+1]
+		x := nilsafe.Uint64n(r, uint64(b)*rest[b]+rest[b+1])
+		if x < uint64(b)*rest[b] {
+			s[i] = int(x / rest[b])
+		} else {
+			s[i] = b
+			b++
+		}
+	}
+	return s
+}
+
+// DyckPath returns a uniformly distributed pseudo-random Dyck path of semilength n,
+// as a slice of 2n steps (true for up and false for down) where every prefix contains
+// at least as many up steps as down steps. It panics if n < 0.
+func DyckPath(r *rand.Rand, n int) []bool {
+	if n < 0 {
+		panic("invalid argument to DyckPath")
+	}
+	// cycle lemma: exactly one rotation of a sequence of n up and n+1 down steps
+	// has all proper prefixes non-negative, and dropping its final down step gives a Dyck path
+	steps := make([]bool, 2*n+1)
+	for i := 0; i < n; i++ {
+		steps[i] = true
+	}
+	rand.ShuffleSlice(r, steps)
+	start, h, minH := 0, 0, 0
+	for i, up := range steps {
+		if up {
+			h++
+		} else {
+			h--
+		}
+		if h < minH {
+			minH = h
+			start = i + 1
+		}
+	}
+	path := make([]bool, 0, 2*n+1)
+	path = append(path, steps[start:]...)
+	path = append(path, steps[:start]...)
+	return path[:2*n]
+}
+
+// BinaryTree returns a uniformly distributed pseudo-random binary tree with n nodes,
+// numbered in preorder from 0 (root). left[i] and right[i] are the children of node i, or -1
+// if there is no corresponding child. It panics if n < 0.
+func BinaryTree(r *rand.Rand, n int) (left []int, right []int) {
+	path := DyckPath(r, n)
+	// standard bijection: path = up, path of the left subtree, down, path of the right subtree
+	match := make([]int, len(path))
+	var stack []int
+	for i, up := range path {
+		if up {
+			stack = append(stack, i)
+		} else {
+			match[stack[len(stack)-1]] = i
+			stack = stack[:len(stack)-1]
+		}
+	}
+	left = make([]int, n)
+	right = make([]int, n)
+	next := 0
+	var build func(lo int, hi int) int
+	build = func(lo int, hi int) int {
+		if lo == hi {
+			return -1
+		}
+		node := next
+		next++
+		m := match[lo]
+		left[node] = build(lo+1, m)
+		right[node] = build(m+1, hi)
+		return node
+	}
+	build(0, len(path))
+	return left, right
+}
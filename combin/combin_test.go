@@ -0,0 +1,241 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package combin_test
+
+import (
+	"fmt"
+	"pgregory.net/rand"
+	"pgregory.net/rand/combin"
+	"pgregory.net/rand/randtest"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+// checkUniformObjects checks that fn generates exactly want distinct objects (identified by their
+// string representation), and that they are uniformly distributed.
+func checkUniformObjects(t *testing.T, want int, fn func() string) {
+	t.Helper()
+	iters := 1000 * want
+	seen := map[string]int{}
+	for i := 0; i < iters; i++ {
+		seen[fn()]++
+	}
+	if len(seen) != want {
+		t.Fatalf("got %v distinct objects instead of %v", len(seen), want)
+	}
+	if want < 2 {
+		return
+	}
+	counts := make([]int, 0, want)
+	probs := make([]float64, 0, want)
+	for _, c := range seen {
+		counts = append(counts, c)
+		probs = append(probs, 1/float64(want))
+	}
+	randtest.Check(t, "χ²", randtest.ChiSquare(counts, probs), randtest.DefaultAlpha)
+}
+
+func binomial(n int, k int) int {
+	c := 1
+	for i := 1; i <= k; i++ {
+		c = c * (n - k + i) / i
+	}
+	return c
+}
+
+func TestSubset(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(0, 1000).Draw(t, "n").(int)
+		k := rapid.IntRange(0, n).Draw(t, "k").(int)
+		s := combin.Subset(r, n, k)
+		if len(s) != k {
+			t.Fatalf("got %v elements instead of %v", len(s), k)
+		}
+		for i, x := range s {
+			if x < 0 || x >= n || (i > 0 && x <= s[i-1]) {
+				t.Fatalf("invalid subset %v of [0, %v)", s, n)
+			}
+		}
+	})
+}
+
+func TestComposition(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(0, 1000).Draw(t, "n").(int)
+		k := rapid.IntRange(0, n).Draw(t, "k").(int)
+		if n > 0 && k == 0 {
+			k = 1
+		}
+		c := combin.Composition(r, n, k)
+		sum := 0
+		for _, x := range c {
+			if x <= 0 {
+				t.Fatalf("non-positive part in %v", c)
+			}
+			sum += x
+		}
+		if len(c) != k || sum != n {
+			t.Fatalf("invalid composition %v of %v into %v parts", c, n, k)
+		}
+	})
+}
+
+func TestPartition(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(0, combin.MaxPartition).Draw(t, "n").(int)
+		p := combin.Partition(r, n)
+		sum := 0
+		for i, x := range p {
+			if x <= 0 || (i > 0 && x > p[i-1]) {
+				t.Fatalf("invalid partition %v", p)
+			}
+			sum += x
+		}
+		if sum != n {
+			t.Fatalf("partition %v sums up to %v instead of %v", p, sum, n)
+		}
+	})
+}
+
+func TestSetPartition(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(0, combin.MaxSetPartition).Draw(t, "n").(int)
+		s := combin.SetPartition(r, n)
+		blocks := 0
+		for _, b := range s {
+			if b < 0 || b > blocks {
+				t.Fatalf("invalid restricted growth string %v", s)
+			}
+			if b == blocks {
+				blocks++
+			}
+		}
+	})
+}
+
+func TestDyckPath(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(0, 1000).Draw(t, "n").(int)
+		p := combin.DyckPath(r, n)
+		if len(p) != 2*n {
+			t.Fatalf("got %v steps instead of %v", len(p), 2*n)
+		}
+		h := 0
+		for _, up := range p {
+			if up {
+				h++
+			} else {
+				h--
+			}
+			if h < 0 {
+				t.Fatalf("path %v goes below zero", p)
+			}
+		}
+		if h != 0 {
+			t.Fatalf("path %v ends at height %v", p, h)
+		}
+	})
+}
+
+func TestBinaryTree(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(0, 1000).Draw(t, "n").(int)
+		left, right := combin.BinaryTree(r, n)
+		if len(left) != n || len(right) != n {
+			t.Fatalf("got %v/%v nodes instead of %v", len(left), len(right), n)
+		}
+		// in preorder numbering, every child has larger number than its parent,
+		// and every node except the root is a child exactly once
+		parents := make([]int, n)
+		for i := 0; i < n; i++ {
+			for _, c := range []int{left[i], right[i]} {
+				if c != -1 {
+					if c <= i || c >= n {
+						t.Fatalf("invalid child %v of node %v", c, i)
+					}
+					parents[c]++
+				}
+			}
+		}
+		for i, p := range parents {
+			if i == 0 && p != 0 || i > 0 && p != 1 {
+				t.Fatalf("node %v has %v parents", i, p)
+			}
+		}
+	})
+}
+
+func TestUniformSubset(t *testing.T) {
+	for _, n := range []int{4, 6} {
+		for k := 0; k <= n; k++ {
+			t.Run(fmt.Sprintf("n=%d,k=%d", n, k), func(t *testing.T) {
+				r := rand.New(1)
+				checkUniformObjects(t, binomial(n, k), func() string { return fmt.Sprint(combin.Subset(r, n, k)) })
+			})
+		}
+	}
+}
+
+func TestUniformComposition(t *testing.T) {
+	for n := 1; n <= 7; n++ {
+		for k := 1; k <= n; k++ {
+			t.Run(fmt.Sprintf("n=%d,k=%d", n, k), func(t *testing.T) {
+				r := rand.New(1)
+				checkUniformObjects(t, binomial(n-1, k-1), func() string { return fmt.Sprint(combin.Composition(r, n, k)) })
+			})
+		}
+	}
+}
+
+func TestUniformPartition(t *testing.T) {
+	partitions := []int{1, 1, 2, 3, 5, 7, 11, 15, 22, 30, 42}
+	for n, want := range partitions {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			r := rand.New(1)
+			checkUniformObjects(t, want, func() string { return fmt.Sprint(combin.Partition(r, n)) })
+		})
+	}
+}
+
+func TestUniformSetPartition(t *testing.T) {
+	bell := []int{1, 1, 2, 5, 15, 52, 203}
+	for n, want := range bell {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			r := rand.New(1)
+			checkUniformObjects(t, want, func() string { return fmt.Sprint(combin.SetPartition(r, n)) })
+		})
+	}
+}
+
+func TestUniformDyckPath(t *testing.T) {
+	catalan := []int{1, 1, 2, 5, 14, 42, 132}
+	for n, want := range catalan {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			r := rand.New(1)
+			checkUniformObjects(t, want, func() string { return fmt.Sprint(combin.DyckPath(r, n)) })
+		})
+	}
+}
+
+func TestUniformBinaryTree(t *testing.T) {
+	catalan := []int{1, 1, 2, 5, 14, 42, 132}
+	for n, want := range catalan {
+		t.Run(fmt.Sprintf("n=%d", n), func(t *testing.T) {
+			r := rand.New(1)
+			checkUniformObjects(t, want, func() string {
+				left, right := combin.BinaryTree(r, n)
+				return fmt.Sprint(left, right)
+			})
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math"
+
+// Pareto returns a Pareto distributed float64 in the range [xm, +Inf)
+// with scale xm and shape alpha. It panics if xm <= 0 or alpha <= 0.
+func (r *Rand) Pareto(xm float64, alpha float64) float64 {
+	if !(xm > 0) || !(alpha > 0) {
+		panic("invalid argument to Pareto")
+	}
+	return xm * math.Exp(r.ExpFloat64()/alpha)
+}
+
+// LogNormal returns a log-normally distributed float64 in the range [0, +Inf),
+// whose logarithm is normally distributed with mean mu and standard deviation sigma.
+// It panics if sigma < 0.
+func (r *Rand) LogNormal(mu float64, sigma float64) float64 {
+	if !(sigma >= 0) {
+		panic("invalid argument to LogNormal")
+	}
+	return math.Exp(mu + sigma*r.NormFloat64())
+}
+
+// Weibull returns a Weibull distributed float64 in the range [0, +Inf)
+// with shape k and scale lambda. It panics if k <= 0 or lambda <= 0.
+func (r *Rand) Weibull(k float64, lambda float64) float64 {
+	if !(k > 0) || !(lambda > 0) {
+		panic("invalid argument to Weibull")
+	}
+	return lambda * math.Pow(r.ExpFloat64(), 1/k)
+}
+
+// Cauchy returns a Cauchy distributed float64 with location x0 and scale gamma.
+// It panics if gamma <= 0.
+func (r *Rand) Cauchy(x0 float64, gamma float64) float64 {
+	if !(gamma > 0) {
+		panic("invalid argument to Cauchy")
+	}
+	return x0 + gamma*math.Tan(math.Pi*(r.Float64()-0.5))
+}
+
+// Levy returns a Lévy distributed float64 in the range [mu, +Inf]
+// with location mu and scale c. It panics if c <= 0.
+func (r *Rand) Levy(mu float64, c float64) float64 {
+	if !(c > 0) {
+		panic("invalid argument to Levy")
+	}
+	n := r.NormFloat64()
+	return mu + c/(n*n)
+}
+
+// Pareto returns a Pareto distributed float64 in the range [xm, +Inf)
+// with scale xm and shape alpha. It panics if xm <= 0 or alpha <= 0.
+func Pareto(xm float64, alpha float64) float64 {
+	if !(xm > 0) || !(alpha > 0) {
+		panic("invalid argument to Pareto")
+	}
+	return xm * math.Exp(ExpFloat64()/alpha)
+}
+
+// LogNormal returns a log-normally distributed float64 in the range [0, +Inf),
+// whose logarithm is normally distributed with mean mu and standard deviation sigma.
+// It panics if sigma < 0.
+func LogNormal(mu float64, sigma float64) float64 {
+	if !(sigma >= 0) {
+		panic("invalid argument to LogNormal")
+	}
+	return math.Exp(mu + sigma*NormFloat64())
+}
+
+// Weibull returns a Weibull distributed float64 in the range [0, +Inf)
+// with shape k and scale lambda. It panics if k <= 0 or lambda <= 0.
+func Weibull(k float64, lambda float64) float64 {
+	if !(k > 0) || !(lambda > 0) {
+		panic("invalid argument to Weibull")
+	}
+	return lambda * math.Pow(ExpFloat64(), 1/k)
+}
+
+// Cauchy returns a Cauchy distributed float64 with location x0 and scale gamma.
+// It panics if gamma <= 0.
+func Cauchy(x0 float64, gamma float64) float64 {
+	if !(gamma > 0) {
+		panic("invalid argument to Cauchy")
+	}
+	return x0 + gamma*math.Tan(math.Pi*(Float64()-0.5))
+}
+
+// Levy returns a Lévy distributed float64 in the range [mu, +Inf]
+// with location mu and scale c. It panics if c <= 0.
+func Levy(mu float64, c float64) float64 {
+	if !(c > 0) {
+		panic("invalid argument to Levy")
+	}
+	n := NormFloat64()
+	return mu + c/(n*n)
+}
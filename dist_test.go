@@ -0,0 +1,92 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"fmt"
+	"math"
+	"pgregory.net/rand"
+	"sort"
+	"testing"
+)
+
+var testQuantiles = []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999}
+
+// checkQuantiles checks that for every p from testQuantiles, the fraction of samples
+// not exceeding quantile(p) is close to p, allowing for 5 binomial standard deviations.
+func checkQuantiles(t *testing.T, samples []float64, quantile func(float64) float64) {
+	t.Helper()
+	sort.Float64s(samples)
+	n := float64(len(samples))
+	for _, p := range testQuantiles {
+		x := quantile(p)
+		k := sort.Search(len(samples), func(i int) bool { return samples[i] > x })
+		if math.Abs(float64(k)/n-p) > 5*math.Sqrt(p*(1-p)/n) {
+			t.Errorf("%v of samples <= %v, expected %v", float64(k)/n, x, p)
+		}
+	}
+}
+
+func normQuantile(p float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*p-1)
+}
+
+func TestHeavyTailedQuantiles(t *testing.T) {
+	const n = 100000
+	tests := []struct {
+		name     string
+		gen      func(*rand.Rand) float64
+		quantile func(float64) float64
+	}{
+		{"Pareto(1,1)", func(r *rand.Rand) float64 { return r.Pareto(1, 1) }, func(p float64) float64 { return math.Pow(1-p, -1) }},
+		{"Pareto(2,0.5)", func(r *rand.Rand) float64 { return r.Pareto(2, 0.5) }, func(p float64) float64 { return 2 * math.Pow(1-p, -2) }},
+		{"LogNormal(0,1)", func(r *rand.Rand) float64 { return r.LogNormal(0, 1) }, func(p float64) float64 { return math.Exp(normQuantile(p)) }},
+		{"LogNormal(3,2)", func(r *rand.Rand) float64 { return r.LogNormal(3, 2) }, func(p float64) float64 { return math.Exp(3 + 2*normQuantile(p)) }},
+		{"Weibull(0.5,1)", func(r *rand.Rand) float64 { return r.Weibull(0.5, 1) }, func(p float64) float64 { return math.Pow(-math.Log1p(-p), 2) }},
+		{"Weibull(3,10)", func(r *rand.Rand) float64 { return r.Weibull(3, 10) }, func(p float64) float64 { return 10 * math.Cbrt(-math.Log1p(-p)) }},
+		{"Cauchy(0,1)", func(r *rand.Rand) float64 { return r.Cauchy(0, 1) }, func(p float64) float64 { return math.Tan(math.Pi * (p - 0.5)) }},
+		{"Cauchy(-5,0.1)", func(r *rand.Rand) float64 { return r.Cauchy(-5, 0.1) }, func(p float64) float64 { return -5 + 0.1*math.Tan(math.Pi*(p-0.5)) }},
+		{"Levy(0,1)", func(r *rand.Rand) float64 { return r.Levy(0, 1) }, func(p float64) float64 { e := math.Erfcinv(p); return 1 / (2 * e * e) }},
+		{"Levy(1,3)", func(r *rand.Rand) float64 { return r.Levy(1, 3) }, func(p float64) float64 { e := math.Erfcinv(p); return 1 + 3/(2*e*e) }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, seed := range testSeeds {
+				t.Run(fmt.Sprint(seed), func(t *testing.T) {
+					r := rand.New(uint64(seed))
+					samples := make([]float64, n)
+					for i := range samples {
+						samples[i] = test.gen(r)
+					}
+					checkQuantiles(t, samples, test.quantile)
+				})
+			}
+		})
+	}
+}
+
+func TestHeavyTailedGlobal(t *testing.T) {
+	const n = 10000
+	tests := []struct {
+		name string
+		gen  func() float64
+		min  float64
+	}{
+		{"Pareto", func() float64 { return rand.Pareto(2, 1) }, 2},
+		{"LogNormal", func() float64 { return rand.LogNormal(0, 1) }, 0},
+		{"Weibull", func() float64 { return rand.Weibull(2, 1) }, 0},
+		{"Cauchy", func() float64 { return rand.Cauchy(0, 1) }, math.Inf(-1)},
+		{"Levy", func() float64 { return rand.Levy(1, 1) }, 1},
+	}
+	for _, test := range tests {
+		for i := 0; i < n; i++ {
+			if x := test.gen(); math.IsNaN(x) || x < test.min {
+				t.Fatalf("%v: got %v less than %v", test.name, x, test.min)
+			}
+		}
+	}
+}
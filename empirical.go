@@ -0,0 +1,156 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"math"
+	"sort"
+)
+
+// An Empirical generates values from an empirical distribution,
+// described by a set of samples or by a histogram.
+type Empirical struct {
+	r      *Rand
+	x      []float64 // sorted samples or bucket edges
+	cum    []float64 // cumulative bucket weights; nil for samples
+	interp bool
+}
+
+// NewEmpirical returns an Empirical that generates values distributed like samples.
+// When interpolate is false, Empirical returns one of the samples, chosen uniformly at random.
+// Otherwise, it samples from the continuous distribution whose CDF linearly interpolates
+// between the sorted samples. NewEmpirical panics if samples is empty or contains NaN values.
+//
+// When r is nil, Empirical uses non-deterministic goroutine-local pseudo-random data source.
+func NewEmpirical(r *Rand, samples []float64, interpolate bool) *Empirical {
+	if len(samples) == 0 {
+		panic("invalid argument to NewEmpirical")
+	}
+	x := append([]float64(nil), samples...)
+	for _, v := range x {
+		if math.IsNaN(v) {
+			panic("invalid argument to NewEmpirical")
+		}
+	}
+	sort.Float64s(x)
+	return &Empirical{r: r, x: x, interp: interpolate}
+}
+
+// NewEmpiricalHistogram returns an Empirical that generates values distributed according to a histogram,
+// where bucket i covers the half-open interval [edges[i], edges[i+1]) and has weight weights[i].
+// When interpolate is false, Empirical returns the left edge of the chosen bucket. Otherwise, values are
+// distributed uniformly within the bucket (in other words, the CDF is linearly interpolated between the edges).
+// NewEmpiricalHistogram panics if len(edges) != len(weights)+1, edges are not finite and strictly increasing,
+// or weights are negative, not finite, all zero or have an infinite sum.
+//
+// When r is nil, Empirical uses non-deterministic goroutine-local pseudo-random data source.
+func NewEmpiricalHistogram(r *Rand, edges []float64, weights []float64, interpolate bool) *Empirical {
+	if len(weights) == 0 || len(edges) != len(weights)+1 {
+		panic("invalid argument to NewEmpiricalHistogram")
+	}
+	for i, v := range edges {
+		if math.IsNaN(v) || math.IsInf(v, 0) || (i > 0 && !(edges[i-1] < v)) {
+			panic("invalid argument to NewEmpiricalHistogram")
+		}
+	}
+	cum := make([]float64, len(weights))
+	total := 0.0
+	for i, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			panic("invalid argument to NewEmpiricalHistogram")
+		}
+		total += w
+		cum[i] = total
+	}
+	if total == 0 || math.IsInf(total, 1) {
+		panic("invalid argument to NewEmpiricalHistogram")
+	}
+	return &Empirical{r: r, x: append([]float64(nil), edges...), cum: cum, interp: interpolate}
+}
+
+// NewEmpiricalCDF returns an Empirical that approximates the distribution with the given CDF,
+// restricted to [edges[0], edges[len(edges)-1]). The CDF is evaluated at every edge, and linearly
+// interpolated between them. NewEmpiricalCDF panics if edges are not finite and strictly increasing,
+// or cdf is not finite, decreasing or constant over the edges.
+//
+// When r is nil, Empirical uses non-deterministic goroutine-local pseudo-random data source.
+func NewEmpiricalCDF(r *Rand, cdf func(float64) float64, edges []float64) *Empirical {
+	if len(edges) < 2 {
+		panic("invalid argument to NewEmpiricalCDF")
+	}
+	for i, v := range edges {
+		if math.IsNaN(v) || math.IsInf(v, 0) || (i > 0 && !(edges[i-1] < v)) {
+			panic("invalid argument to NewEmpiricalCDF")
+		}
+	}
+	weights := make([]float64, len(edges)-1)
+	first := cdf(edges[0])
+	prev := first
+	for i := range weights {
+		c := cdf(edges[i+1])
+		w := c - prev
+		if !(w >= 0) || math.IsInf(w, 1) {
+			panic("invalid argument to NewEmpiricalCDF")
+		}
+		weights[i] = w
+		prev = c
+	}
+	if !(prev-first > 0) || math.IsInf(prev-first, 1) {
+		panic("invalid argument to NewEmpiricalCDF")
+	}
+	return NewEmpiricalHistogram(r, edges, weights, true)
+}
+
+// Float64 returns a value drawn from the empirical distribution described by the Empirical object.
+func (e *Empirical) Float64() float64 {
+	if e.cum == nil && (!e.interp || len(e.x) == 1) {
+		if e.r == nil {
+			return e.x[Intn(len(e.x))]
+		}
+		return e.x[e.r.Intn(len(e.x))]
+	}
+
+	var u float64
+	if e.r == nil {
+		u = Float64()
+	} else {
+		u = e.r.Float64()
+	}
+	if e.cum == nil {
+		u *= float64(len(e.x) - 1)
+		i := int(u)
+		return e.x[i] + (u-float64(i))*(e.x[i+1]-e.x[i])
+	}
+
+	// the same uniform value selects the bucket and the position inside it
+	u *= e.cum[len(e.cum)-1]
+	i := sort.Search(len(e.cum)-1, func(i int) bool { return e.cum[i] > u })
+	if !e.interp {
+		return e.x[i]
+	}
+	lo := 0.0
+	if i > 0 {
+		lo = e.cum[i-1]
+	}
+	f := (u - lo) / (e.cum[i] - lo)
+	if f >= 1 {
+		f = math.Nextafter(1, 0) // floating-point rounding
+	}
+	return e.x[i] + f*(e.x[i+1]-e.x[i])
+}
+
+// InverseCDF returns a value from the distribution with the given quantile function (inverse CDF),
+// by evaluating it at a uniformly distributed pseudo-random point in the open interval (0.0, 1.0).
+func (r *Rand) InverseCDF(quantile func(float64) float64) float64 {
+	return quantile((float64(r.next64()>>11) + 0.5) * f53Mul)
+}
+
+// InverseCDF returns a value from the distribution with the given quantile function (inverse CDF),
+// by evaluating it at a uniformly distributed pseudo-random point in the open interval (0.0, 1.0).
+func InverseCDF(quantile func(float64) float64) float64 {
+	return quantile((float64(rand64()>>11) + 0.5) * f53Mul)
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"sort"
+	"testing"
+)
+
+func TestEmpirical(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		var r *rand.Rand
+		if rapid.Bool().Draw(t, "seeded").(bool) {
+			r = rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		}
+		samples := rapid.SliceOfN(rapid.Float64Range(-1e6, 1e6), 1, small).Draw(t, "samples").([]float64)
+		interpolate := rapid.Bool().Draw(t, "interpolate").(bool)
+		e := rand.NewEmpirical(r, samples, interpolate)
+		x := e.Float64()
+		sorted := append([]float64(nil), samples...)
+		sort.Float64s(sorted)
+		if x < sorted[0] || x > sorted[len(sorted)-1] {
+			t.Fatalf("got %v outside of [%v, %v]", x, sorted[0], sorted[len(sorted)-1])
+		}
+		if !interpolate {
+			if i := sort.SearchFloat64s(sorted, x); sorted[i] != x {
+				t.Fatalf("got %v not from samples", x)
+			}
+		}
+	})
+}
+
+func TestEmpiricalHistogram(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		weights := rapid.SliceOfN(rapid.SampledFrom([]float64{0, 0.5, 1, 100}), 1, small).Draw(t, "weights").([]float64)
+		weights[0] = 1
+		edges := make([]float64, len(weights)+1)
+		for i := range edges {
+			edges[i] = float64(i * i)
+		}
+		interpolate := rapid.Bool().Draw(t, "interpolate").(bool)
+		x := rand.NewEmpiricalHistogram(r, edges, weights, interpolate).Float64()
+		i := sort.Search(len(edges), func(i int) bool { return edges[i] > x }) - 1
+		if i < 0 || i >= len(weights) {
+			t.Fatalf("got %v outside of [%v, %v)", x, edges[0], edges[len(edges)-1])
+		}
+		if weights[i] == 0 {
+			t.Fatalf("got %v from bucket %v with zero weight", x, i)
+		}
+		if !interpolate && x != edges[i] {
+			t.Fatalf("got %v instead of bucket edge %v", x, edges[i])
+		}
+	})
+}
+
+func TestEmpiricalSamplesDistribution(t *testing.T) {
+	// interpolated empirical distribution of evenly spaced samples is uniform
+	const n = 20000
+	samples := make([]float64, 11)
+	for i := range samples {
+		samples[i] = float64(i)
+	}
+	e := rand.NewEmpirical(rand.New(uint64(testSeeds[0])), samples, true)
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = e.Float64()
+	}
+	checkCDF(t, values, func(x float64) float64 { return x / 10 })
+}
+
+func TestEmpiricalHistogramDistribution(t *testing.T) {
+	const n = 20000
+	edges := []float64{0, 1, 3, 4}
+	weights := []float64{1, 2, 1}
+	e := rand.NewEmpiricalHistogram(rand.New(uint64(testSeeds[0])), edges, weights, true)
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = e.Float64()
+	}
+	checkCDF(t, values, func(x float64) float64 { return x / 4 })
+}
+
+func TestEmpiricalCDFDistribution(t *testing.T) {
+	const n = 20000
+	edges := make([]float64, 1001)
+	for i := range edges {
+		edges[i] = -8 + 16*float64(i)/float64(len(edges)-1)
+	}
+	cdf := func(x float64) float64 { return 1 - normSF(x) }
+	e := rand.NewEmpiricalCDF(rand.New(uint64(testSeeds[0])), cdf, edges)
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = e.Float64()
+	}
+	checkCDF(t, values, cdf)
+}
+
+func TestRand_InverseCDF(t *testing.T) {
+	const n = 20000
+	r := rand.New(uint64(testSeeds[0]))
+	quantile := func(p float64) float64 { return -math.Log1p(-p) }
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = r.InverseCDF(quantile)
+		if math.IsInf(values[i], 0) {
+			t.Fatalf("got infinite value")
+		}
+	}
+	checkCDF(t, values, func(x float64) float64 { return -math.Expm1(-x) })
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+// FromBytes returns a generator for fuzzing code that accepts a [Rand]; without the randfuzz
+// build tag, its outputs are not steered by data, and it is merely seeded with a hash of data.
+//
+// When built with the randfuzz build tag (for example, go test -fuzz=. -tags=randfuzz),
+// every 64-bit output of the generator is taken from data: 8 bytes at a time, in big-endian order,
+// so that the first byte of each output mostly determines results of methods like [Rand.Intn],
+// [Rand.Float64] or [Rand.Perm], and fuzzers can steer them directly. The last incomplete
+// output is padded with zero bytes. When data is exhausted, the generator produces
+// a deterministic pseudo-random stream seeded with a hash of data.
+//
+// The tag is required to keep other generators as fast as possible. Without it, FromBytes
+// still makes fuzz targets (and their seed corpora) deterministic, but coverage-guided
+// fuzzing can only explore the outputs by chance.
+func FromBytes(data []byte) *Rand {
+	var r Rand
+	r.init1(fnv64(data))
+	r.initFuzz(data)
+	return &r
+}
+
+// FromFunc returns a generator with every 64-bit output being the result of calling next;
+// it requires the randfuzz build tag, and panics without it.
+//
+// The tag is required to keep other generators as fast as possible.
+func FromFunc(next func() uint64) *Rand {
+	var r Rand
+	r.initFunc(next)
+	return &r
+}
+
+// fnv64 returns the 64-bit FNV-1a hash of data.
+func fnv64(data []byte) uint64 {
+	h := uint64(14695981039346656037)
+	for _, b := range data {
+		h ^= uint64(b)
+		h *= 1099511628211
+	}
+	return h
+}
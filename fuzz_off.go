@@ -0,0 +1,21 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build !randfuzz
+
+package rand
+
+type fuzzState struct{}
+
+func (r *Rand) initFuzz(_ []byte) {}
+
+func (r *Rand) initFunc(_ func() uint64) {
+	panic("FromFunc requires randfuzz build tag")
+}
+
+func (r *Rand) next64() uint64 {
+	return r.sfc64.next64()
+}
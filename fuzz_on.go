@@ -0,0 +1,42 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build randfuzz
+
+package rand
+
+import "encoding/binary"
+
+type fuzzState struct {
+	data []byte
+	next func() uint64
+}
+
+func (r *Rand) initFuzz(data []byte) {
+	r.data = data
+}
+
+func (r *Rand) initFunc(next func() uint64) {
+	r.next = next
+}
+
+func (r *Rand) next64() uint64 {
+	if r.next != nil {
+		return r.next()
+	}
+	if len(r.data) == 0 {
+		return r.sfc64.next64()
+	}
+	if len(r.data) >= 8 {
+		u := binary.BigEndian.Uint64(r.data)
+		r.data = r.data[8:]
+		return u
+	}
+	var buf [8]byte
+	copy(buf[:], r.data)
+	r.data = nil
+	return binary.BigEndian.Uint64(buf[:])
+}
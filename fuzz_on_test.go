@@ -0,0 +1,83 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build randfuzz
+
+package rand_test
+
+import (
+	"encoding/binary"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func TestFromBytes_Data(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		data := rapid.SliceOf(rapid.Byte()).Draw(t, "data").([]byte)
+		r := rand.FromBytes(data)
+		for i := 0; i < (len(data)+7)/8; i++ {
+			var buf [8]byte
+			copy(buf[:], data[8*i:])
+			if u, want := r.Uint64(), binary.BigEndian.Uint64(buf[:]); u != want {
+				t.Fatalf("got output #%v %#x instead of %#x", i, u, want)
+			}
+		}
+		// after the data is exhausted, stream continues deterministically
+		r2 := rand.FromBytes(append([]byte(nil), data...))
+		for i := 0; i < (len(data)+7)/8; i++ {
+			r2.Uint64()
+		}
+		for i := 0; i < 10; i++ {
+			if u1, u2 := r.Uint64(), r2.Uint64(); u1 != u2 {
+				t.Fatalf("got different fallback outputs #%v: %v vs %v", i, u1, u2)
+			}
+		}
+	})
+}
+
+func TestFromBytes_Steering(t *testing.T) {
+	// first byte of an output determines the result of Intn for small n
+	for b := 0; b < 256; b++ {
+		r := rand.FromBytes([]byte{byte(b)})
+		if got, want := r.Intn(4), b/64; got != want {
+			t.Errorf("Intn(4) for first byte %v: got %v instead of %v", b, got, want)
+		}
+	}
+}
+
+func TestFromFunc(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		outputs := rapid.SliceOf(rapid.Uint64()).Draw(t, "outputs").([]uint64)
+		i := 0
+		r := rand.FromFunc(func() uint64 {
+			u := outputs[i%len(outputs)]
+			i++
+			return u
+		})
+		if len(outputs) == 0 {
+			return
+		}
+		for j := 0; j < 2*len(outputs); j++ {
+			if u, want := r.Uint64(), outputs[j%len(outputs)]; u != want {
+				t.Fatalf("got output #%v %v instead of %v", j, u, want)
+			}
+		}
+	})
+}
+
+func TestFromFunc_Deterministic(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		seed := rapid.Uint64().Draw(t, "seed").(uint64)
+		r1 := rand.FromFunc(rand.New(seed).Uint64)
+		r2 := rand.FromFunc(rand.New(seed).Uint64)
+		for i := 0; i < 10; i++ {
+			if u1, u2 := r1.Uint64(), r2.Uint64(); u1 != u2 {
+				t.Fatalf("got different outputs #%v for the same function: %v vs %v", i, u1, u2)
+			}
+		}
+	})
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func TestFromBytes_Deterministic(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		data := rapid.SliceOf(rapid.Byte()).Draw(t, "data").([]byte)
+		r1 := rand.FromBytes(data)
+		r2 := rand.FromBytes(append([]byte(nil), data...))
+		for i := 0; i < len(data)/8+10; i++ {
+			if u1, u2 := r1.Uint64(), r2.Uint64(); u1 != u2 {
+				t.Fatalf("got different outputs #%v for the same data: %v vs %v", i, u1, u2)
+			}
+		}
+	})
+}
+
+func TestFromBytes_Seed(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		data := rapid.SliceOf(rapid.Byte()).Draw(t, "data").([]byte)
+		seed := rapid.Uint64().Draw(t, "seed").(uint64)
+		r1 := rand.FromBytes(data)
+		r1.Seed(seed)
+		r2 := rand.New(seed)
+		for i := 0; i < 10; i++ {
+			if u1, u2 := r1.Uint64(), r2.Uint64(); u1 != u2 {
+				t.Fatalf("got different outputs #%v after Seed(%v): %v vs %v", i, seed, u1, u2)
+			}
+		}
+	})
+}
+
+func FuzzFromBytes(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0xff, 0, 0, 0, 0, 0, 0, 0, 0x80})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		r := rand.FromBytes(data)
+		n := r.Intn(10) + 1
+		p := r.Perm(n)
+		seen := make([]bool, n)
+		for _, v := range p {
+			if v < 0 || v >= n || seen[v] {
+				t.Fatalf("invalid permutation %v", p)
+			}
+			seen[v] = true
+		}
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math"
+
+const binomialDirectMax = 64
+
+// gamma returns a gamma distributed float64 with shape a > 0 and scale 1.
+func (r *Rand) gamma(a float64) float64 {
+	// "A Simple Method for Generating Gamma Variables" by George Marsaglia and Wai Wan Tsang,
+	// https://dl.acm.org/doi/10.1145/358407.358414
+	if a < 1 {
+		// Gamma(a) = Gamma(a+1) * U^(1/a)
+		return r.gamma(a+1) * math.Pow(r.Float64(), 1/a)
+	}
+	d := a - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := r.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := r.Float64()
+		x2 := x * x
+		if u < 1-0.0331*x2*x2 || math.Log(u) < 0.5*x2+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// beta returns a beta distributed float64 with shapes a > 0 and b > 0.
+func (r *Rand) beta(a float64, b float64) float64 {
+	x := r.gamma(a)
+	y := r.gamma(b)
+	if x+y == 0 {
+		// both shapes are tiny and underflowed; decide by the relative shape
+		if r.Float64()*(a+b) < a {
+			return 1
+		}
+		return 0
+	}
+	return x / (x + y)
+}
+
+// binomial returns the number of successes in n independent trials with success probability p.
+func (r *Rand) binomial(n int, p float64) int {
+	// exact algorithm from Knuth, TAOCP vol. 2, 3.4.1: the a-th smallest of n uniform variables
+	// is Beta(a, n-a+1) distributed, and tells how many of the trials succeeded before or after it
+	k := 0
+	for n > binomialDirectMax {
+		if p <= 0 {
+			return k
+		}
+		if p >= 1 {
+			return k + n
+		}
+		a := 1 + n/2
+		b := n - a + 1
+		x := r.beta(float64(a), float64(b))
+		if x >= p {
+			n = a - 1
+			p /= x
+		} else {
+			k += a
+			n = b - 1
+			p = (p - x) / (1 - x)
+		}
+	}
+	for i := 0; i < n; i++ {
+		if r.Float64() < p {
+			k++
+		}
+	}
+	return k
+}
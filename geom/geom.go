@@ -0,0 +1,112 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package geom implements generation of uniformly distributed pseudo-random points
+// on and inside geometric shapes.
+//
+// All functions accept a [rand.Rand] parameter. When it is nil, functions use
+// non-deterministic goroutine-local pseudo-random data source, and are safe for concurrent use
+// from multiple goroutines. Computations are done in float64 precision for both float32 and float64 points.
+package geom
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rand/internal/nilsafe"
+)
+
+// Float is a constraint for point coordinate types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// OnSphere fills dst with the coordinates of a uniformly distributed pseudo-random point
+// on the surface of the unit sphere in len(dst) dimensions (unit circle when len(dst) == 2).
+// OnSphere panics if len(dst) == 0.
+func OnSphere[F Float](r *rand.Rand, dst []F) {
+	if len(dst) == 0 {
+		panic("invalid argument to OnSphere")
+	}
+	for i, v := range direction(r, len(dst)) {
+		dst[i] = F(v)
+	}
+}
+
+// InBall fills dst with the coordinates of a uniformly distributed pseudo-random point
+// inside the unit ball in len(dst) dimensions (unit disk when len(dst) == 2).
+// InBall panics if len(dst) == 0.
+func InBall[F Float](r *rand.Rand, dst []F) {
+	if len(dst) == 0 {
+		panic("invalid argument to InBall")
+	}
+	d := direction(r, len(dst))
+	// radius of a uniform point inside a n-ball is distributed as U^(1/n)
+	radius := math.Pow(nilsafe.Float64(r), 1/float64(len(dst)))
+	for i, v := range d {
+		dst[i] = F(v * radius)
+	}
+}
+
+// OnSimplex fills dst with the coordinates of a uniformly distributed pseudo-random point
+// on the standard probability simplex: all coordinates are non-negative and sum to 1.
+// This is equivalent to sampling from a Dirichlet distribution with all concentration parameters equal to 1.
+// OnSimplex panics if len(dst) == 0.
+func OnSimplex[F Float](r *rand.Rand, dst []F) {
+	if len(dst) == 0 {
+		panic("invalid argument to OnSimplex")
+	}
+	sum := 0.0
+	e := make([]float64, len(dst))
+	for i := range e {
+		e[i] = nilsafe.ExpFloat64(r)
+		sum += e[i]
+	}
+	for i, v := range e {
+		dst[i] = F(v / sum)
+	}
+}
+
+// InTriangle fills dst with the coordinates of a uniformly distributed pseudo-random point
+// inside the triangle with vertices a, b and c. Points can have any number of dimensions.
+// InTriangle panics if a, b and c do not have the same length as dst.
+func InTriangle[F Float](r *rand.Rand, dst []F, a []F, b []F, c []F) {
+	if len(a) != len(dst) || len(b) != len(dst) || len(c) != len(dst) {
+		panic("invalid argument to InTriangle")
+	}
+	inTriangle(r, dst, a, b, c)
+}
+
+func inTriangle[F Float, V Float](r *rand.Rand, dst []F, a []V, b []V, c []V) {
+	u, v := nilsafe.Float64(r), nilsafe.Float64(r)
+	if u+v > 1 {
+		u, v = 1-u, 1-v
+	}
+	for i := range dst {
+		ai := float64(a[i])
+		dst[i] = F(ai + u*(float64(b[i])-ai) + v*(float64(c[i])-ai))
+	}
+}
+
+// direction returns a uniformly distributed pseudo-random unit vector in n dimensions.
+func direction(r *rand.Rand, n int) []float64 {
+	// normal distribution is spherically symmetric, see Marsaglia (1972)
+	v := make([]float64, n)
+	for {
+		norm := 0.0
+		for i := range v {
+			v[i] = nilsafe.NormFloat64(r)
+			norm += v[i] * v[i]
+		}
+		if norm == 0 {
+			continue
+		}
+		scale := 1 / math.Sqrt(norm)
+		for i := range v {
+			v[i] *= scale
+		}
+		return v
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package geom_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rand/geom"
+	"pgregory.net/rand/randtest"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+const numSamples = 100000
+
+// checkUniformBins checks that counts are consistent with a uniform distribution over the bins.
+func checkUniformBins(t *testing.T, counts []int) {
+	t.Helper()
+	probs := make([]float64, len(counts))
+	for i := range probs {
+		probs[i] = 1 / float64(len(counts))
+	}
+	randtest.Check(t, "χ²", randtest.ChiSquare(counts, probs), randtest.DefaultAlpha)
+}
+
+func bin(x float64, n int) int {
+	b := int(x * float64(n))
+	if b < 0 {
+		return 0
+	}
+	if b >= n {
+		return n - 1
+	}
+	return b
+}
+
+func norm[F geom.Float](v []F) float64 {
+	n := 0.0
+	for _, x := range v {
+		n += float64(x) * float64(x)
+	}
+	return math.Sqrt(n)
+}
+
+func TestOnSphere(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		d := rapid.IntRange(1, 10).Draw(t, "d").(int)
+		v64 := make([]float64, d)
+		geom.OnSphere(r, v64)
+		if n := norm(v64); math.Abs(n-1) > 1e-12 {
+			t.Fatalf("got norm %v for %v", n, v64)
+		}
+		v32 := make([]float32, d)
+		geom.OnSphere(r, v32)
+		if n := norm(v32); math.Abs(n-1) > 1e-6 {
+			t.Fatalf("got norm %v for %v", n, v32)
+		}
+	})
+}
+
+func TestOnSphere_Uniform(t *testing.T) {
+	// Archimedes: z coordinate of a uniform point on the unit 2-sphere is uniform in [-1, 1]
+	r := rand.New(1)
+	counts := make([]int, 20)
+	v := make([]float64, 3)
+	for i := 0; i < numSamples; i++ {
+		geom.OnSphere(r, v)
+		counts[bin((v[2]+1)/2, len(counts))]++
+	}
+	checkUniformBins(t, counts)
+}
+
+func TestOnSphere_Circle(t *testing.T) {
+	r := rand.New(1)
+	counts := make([]int, 36)
+	v := make([]float32, 2)
+	for i := 0; i < numSamples; i++ {
+		geom.OnSphere(r, v)
+		a := math.Atan2(float64(v[1]), float64(v[0]))
+		counts[bin((a+math.Pi)/(2*math.Pi), len(counts))]++
+	}
+	checkUniformBins(t, counts)
+}
+
+func TestInBall(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		d := rapid.IntRange(1, 10).Draw(t, "d").(int)
+		v := make([]float64, d)
+		geom.InBall(r, v)
+		if n := norm(v); n > 1 {
+			t.Fatalf("got norm %v for %v", n, v)
+		}
+	})
+}
+
+func TestInBall_Uniform(t *testing.T) {
+	// for a uniform point inside the unit n-ball, |x|^n is uniform in [0, 1)
+	for _, d := range []int{2, 3, 5} {
+		r := rand.New(uint64(d))
+		counts := make([]int, 20)
+		v := make([]float64, d)
+		for i := 0; i < numSamples; i++ {
+			geom.InBall(r, v)
+			counts[bin(math.Pow(norm(v), float64(d)), len(counts))]++
+		}
+		checkUniformBins(t, counts)
+	}
+}
+
+func TestOnSimplex(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		d := rapid.IntRange(1, 10).Draw(t, "d").(int)
+		v := make([]float64, d)
+		geom.OnSimplex(r, v)
+		sum := 0.0
+		for _, x := range v {
+			if x < 0 {
+				t.Fatalf("got negative coordinate in %v", v)
+			}
+			sum += x
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Fatalf("got sum %v for %v", sum, v)
+		}
+	})
+}
+
+func TestOnSimplex_Uniform(t *testing.T) {
+	// marginals of the flat Dirichlet distribution are Beta(1, n-1), with CDF 1 - (1-x)^(n-1)
+	const d = 4
+	r := rand.New(1)
+	counts := make([]int, 20)
+	v := make([]float32, d)
+	for i := 0; i < numSamples; i++ {
+		geom.OnSimplex(r, v)
+		counts[bin(1-math.Pow(1-float64(v[0]), d-1), len(counts))]++
+	}
+	checkUniformBins(t, counts)
+}
+
+func TestInTriangle_Uniform(t *testing.T) {
+	// midpoints split the triangle into 4 triangles of equal area
+	r := rand.New(1)
+	a, b, c := []float64{0, 0}, []float64{1, 0}, []float64{0, 1}
+	counts := make([]int, 4)
+	v := make([]float64, 2)
+	for i := 0; i < numSamples; i++ {
+		geom.InTriangle(r, v, a, b, c)
+		switch {
+		case v[0] < 0 || v[1] < 0 || v[0]+v[1] > 1:
+			t.Fatalf("got %v outside of the triangle", v)
+		case v[0] >= 0.5:
+			counts[0]++
+		case v[1] >= 0.5:
+			counts[1]++
+		case v[0]+v[1] < 0.5:
+			counts[2]++
+		default:
+			counts[3]++
+		}
+	}
+	checkUniformBins(t, counts)
+}
+
+func TestPolygon_Uniform(t *testing.T) {
+	// L-shaped polygon consisting of 3 unit squares
+	ccw := [][2]float64{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}}
+	cw := [][2]float64{{0, 2}, {1, 2}, {1, 1}, {2, 1}, {2, 0}, {0, 0}}
+	for _, vertices := range [][][2]float64{ccw, cw} {
+		p, err := geom.NewPolygon(vertices)
+		if err != nil {
+			t.Fatalf("got unexpected error: %v", err)
+		}
+		r := rand.New(1)
+		counts := make([]int, 3)
+		for i := 0; i < numSamples; i++ {
+			v := p.Sample(r)
+			switch {
+			case v[0] < 0 || v[1] < 0 || v[0] > 2 || v[1] > 2 || (v[0] > 1 && v[1] > 1):
+				t.Fatalf("got %v outside of the polygon", v)
+			case v[0] > 1:
+				counts[0]++
+			case v[1] > 1:
+				counts[1]++
+			default:
+				counts[2]++
+			}
+		}
+		checkUniformBins(t, counts)
+	}
+}
+
+func TestPolygon_Invalid(t *testing.T) {
+	invalid := [][][2]float32{
+		{{0, 0}, {1, 1}},
+		{{0, 0}, {1, 1}, {2, 2}},
+		{{0, 0}, {1, 1}, {1, 0}, {0, 1}}, // bowtie
+	}
+	for _, vertices := range invalid {
+		if _, err := geom.NewPolygon(vertices); err == nil {
+			t.Errorf("got no error for %v", vertices)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package geom
+
+import (
+	"errors"
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rand/internal/nilsafe"
+	"sort"
+)
+
+var errInvalidPolygon = errors.New("geom: polygon must be simple and have non-zero area")
+
+// A Polygon generates uniformly distributed pseudo-random points inside a simple polygon.
+type Polygon[F Float] struct {
+	tris [][3][2]float64
+	cum  []float64 // cumulative triangle areas
+}
+
+// NewPolygon returns a Polygon with the given vertices, listed in either clockwise or counter-clockwise order.
+// The polygon can be non-convex, but must be simple (its edges must not intersect each other).
+// NewPolygon returns an error if the polygon has zero area or is detected to be not simple.
+func NewPolygon[F Float](vertices [][2]F) (*Polygon[F], error) {
+	if len(vertices) < 3 {
+		return nil, errInvalidPolygon
+	}
+	pts := make([][2]float64, len(vertices))
+	for i, v := range vertices {
+		pts[i] = [2]float64{float64(v[0]), float64(v[1])}
+	}
+	area := 0.0
+	for i := range pts {
+		area += cross(pts[i], pts[(i+1)%len(pts)])
+	}
+	area /= 2
+	if area < 0 {
+		area = -area
+		for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+			pts[i], pts[j] = pts[j], pts[i]
+		}
+	}
+
+	tris, ok := triangulate(pts)
+	if !ok {
+		return nil, errInvalidPolygon
+	}
+	p := &Polygon[F]{}
+	total := 0.0
+	for _, t := range tris {
+		a := triangleArea(t[0], t[1], t[2])
+		if a <= 0 {
+			continue
+		}
+		total += a
+		p.tris = append(p.tris, t)
+		p.cum = append(p.cum, total)
+	}
+	if len(p.tris) == 0 || math.Abs(total-area) > 1e-9*area {
+		return nil, errInvalidPolygon // self-intersections make triangles overlap
+	}
+	return p, nil
+}
+
+// Sample returns a uniformly distributed pseudo-random point inside the polygon.
+func (p *Polygon[F]) Sample(r *rand.Rand) [2]F {
+	u := nilsafe.Float64(r) * p.cum[len(p.cum)-1]
+	i := sort.Search(len(p.cum)-1, func(i int) bool { return p.cum[i] > u })
+	t := p.tris[i]
+	var dst [2]F
+	inTriangle(r, dst[:], t[0][:], t[1][:], t[2][:])
+	return dst
+}
+
+// triangulate splits a counter-clockwise simple polygon into triangles using ear clipping.
+func triangulate(pts [][2]float64) ([][3][2]float64, bool) {
+	idx := make([]int, len(pts))
+	for i := range idx {
+		idx[i] = i
+	}
+	var tris [][3][2]float64
+	for len(idx) > 3 {
+		found := false
+		for i := range idx {
+			a := pts[idx[(i+len(idx)-1)%len(idx)]]
+			b := pts[idx[i]]
+			c := pts[idx[(i+1)%len(idx)]]
+			turn := cross2(a, b, c)
+			if turn < 0 {
+				continue // reflex vertex
+			}
+			if turn > 0 && !anyInside(pts, idx, a, b, c) {
+				tris = append(tris, [3][2]float64{a, b, c})
+			} else if turn > 0 {
+				continue
+			}
+			// ear or collinear vertex
+			idx = append(idx[:i], idx[i+1:]...)
+			found = true
+			break
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	tris = append(tris, [3][2]float64{pts[idx[0]], pts[idx[1]], pts[idx[2]]})
+	return tris, true
+}
+
+func anyInside(pts [][2]float64, idx []int, a [2]float64, b [2]float64, c [2]float64) bool {
+	for _, j := range idx {
+		p := pts[j]
+		if p == a || p == b || p == c {
+			continue
+		}
+		if cross2(a, b, p) >= 0 && cross2(b, c, p) >= 0 && cross2(c, a, p) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func cross(a [2]float64, b [2]float64) float64 {
+	return a[0]*b[1] - a[1]*b[0]
+}
+
+func cross2(a [2]float64, b [2]float64, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+func triangleArea(a [2]float64, b [2]float64, c [2]float64) float64 {
+	return cross2(a, b, c) / 2
+}
@@ -75,6 +75,14 @@ func Perm(n int) []int {
 	return p
 }
 
+// PermInto fills dst with a pseudo-random permutation of the integers in the half-open interval [0, len(dst)).
+func PermInto(dst []int) {
+	if len(dst) > 0 {
+		dst[0] = 0
+	}
+	perm(dst)
+}
+
 func perm(p []int) {
 	// see Rand.perm
 	n := len(p)
@@ -159,3 +167,15 @@ func Uint64n(n uint64) uint64 {
 	_, carry := bits.Add64(frac, hi, 0)
 	return res + carry
 }
+
+// Uint128n returns, as a pair of high and low 64-bit halves, a uniformly distributed pseudo-random number
+// in [0, n), where n is the 128-bit number with high and low halves hi and lo. Uint128n(0, 0) returns (0, 0).
+func Uint128n(hi uint64, lo uint64) (uint64, uint64) {
+	// see Rand.Uint128n
+	if hi == 0 {
+		return 0, Uint64n(lo)
+	}
+	xh := rand64()
+	xl := rand64()
+	return mul128n(hi, lo, xh, xl, rand64())
+}
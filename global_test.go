@@ -126,3 +126,17 @@ func TestUint64n(t *testing.T) {
 		}
 	})
 }
+
+func TestUint128n(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		hi := rapid.Uint64().Draw(t, "hi").(uint64)
+		lo := rapid.Uint64().Draw(t, "lo").(uint64)
+		if hi == 0 && lo == 0 {
+			lo = 1
+		}
+		vh, vl := rand.Uint128n(hi, lo)
+		if vh > hi || (vh == hi && vl >= lo) {
+			t.Fatalf("got (%v, %v) outside of [0, (%v, %v))", vh, vl, hi, lo)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"os"
+	"pgregory.net/rand"
+	"pgregory.net/rand/internal/golden"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestGoldenCorpus(t *testing.T) {
+	if strconv.IntSize != 64 {
+		t.Skip("golden corpus assumes 64-bit int")
+	}
+
+	data, err := os.ReadFile("testdata/golden.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := golden.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = golden.Marshal(golden.Generate())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := golden.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const regen = "if the change in outputs is intended, run 'go run ./misc/golden -o testdata/golden.json'"
+	if len(got) != len(want) {
+		t.Fatalf("got %v cases instead of %v; %v", len(got), len(want), regen)
+	}
+	failed := 0
+	for i := range want {
+		if !golden.Equal(want[i], got[i]) {
+			t.Errorf("case %v: got %+v, want %+v", i, got[i], want[i])
+			if failed++; failed == 10 {
+				break
+			}
+		}
+	}
+	if failed > 0 {
+		t.Errorf("golden corpus mismatch; %v", regen)
+	}
+}
+
+func TestGoldenCoverage(t *testing.T) {
+	covered := map[string]bool{}
+	for _, m := range golden.Methods() {
+		covered[m] = true
+	}
+	for _, m := range golden.Skipped {
+		covered[m] = true
+	}
+
+	typ := reflect.TypeOf(&rand.Rand{})
+	for i := 0; i < typ.NumMethod(); i++ {
+		if name := typ.Method(i).Name; !covered[name] {
+			t.Errorf("method %v is not covered by the golden corpus", name)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package graph implements generation of pseudo-random undirected graphs.
+//
+// Graphs have vertices numbered from 0 to n-1, and are represented as lists of edges,
+// without self-loops and multiple edges. Order of edges is unspecified.
+//
+// All functions accept a [rand.Rand] parameter. When it is nil, functions use
+// non-deterministic goroutine-local pseudo-random data source, and are safe for concurrent use
+// from multiple goroutines.
+package graph
+
+import (
+	"math"
+	"math/bits"
+	"pgregory.net/rand"
+	"pgregory.net/rand/internal/nilsafe"
+)
+
+// Edge is an undirected edge between vertices U and V, with U < V.
+type Edge struct {
+	U int
+	V int
+}
+
+func edge(u int, v int) Edge {
+	if u > v {
+		u, v = v, u
+	}
+	return Edge{u, v}
+}
+
+// GNP returns an Erdős–Rényi random graph G(n, p) with n vertices, where every
+// possible edge is present independently with probability p. GNP runs in O(n + m) time,
+// where m is the number of edges generated, which makes it fast for large sparse graphs.
+// It panics if n < 0 or p is outside of [0, 1].
+func GNP(r *rand.Rand, n int, p float64) []Edge {
+	if n < 0 || !(p >= 0 && p <= 1) {
+		panic("invalid argument to GNP")
+	}
+	var edges []Edge
+	if p == 0 {
+		return edges
+	}
+	if p == 1 {
+		for v := 1; v < n; v++ {
+			for u := 0; u < v; u++ {
+				edges = append(edges, Edge{u, v})
+			}
+		}
+		return edges
+	}
+	// "Efficient generation of large random networks" by Vladimir Batagelj and Ulrik Brandes,
+	// https://doi.org/10.1103/PhysRevE.71.036113: skip over absent edges using geometric distribution
+	lp := math.Log1p(-p)
+	v, w := 1, -1
+	for v < n {
+		skip := math.Floor(math.Log1p(-nilsafe.Float64(r)) / lp)
+		if skip >= float64(n)*float64(n) {
+			break
+		}
+		w += 1 + int(skip)
+		for w >= v && v < n {
+			w -= v
+			v++
+		}
+		if v < n {
+			edges = append(edges, Edge{w, v})
+		}
+	}
+	return edges
+}
+
+// GNM returns an Erdős–Rényi random graph G(n, m) with n vertices and m edges,
+// chosen uniformly among all such graphs. It panics if n < 0, m < 0 or m > n*(n-1)/2.
+func GNM(r *rand.Rand, n int, m int) []Edge {
+	if n < 0 || m < 0 {
+		panic("invalid argument to GNM")
+	}
+	if n < 2 {
+		if m > 0 {
+			panic("invalid argument to GNM")
+		}
+		return []Edge{}
+	}
+	hi, lo := bits.Mul64(uint64(n), uint64(n-1))
+	if hi != 0 {
+		// too many possible edges to index them; m edges only fit in memory when m is much smaller,
+		// so distinct random pairs are found quickly
+		chosen := make(map[Edge]struct{}, m)
+		edges := make([]Edge, 0, m)
+		for len(edges) < m {
+			u, v := nilsafe.Intn(r, n), nilsafe.Intn(r, n)
+			e := edge(u, v)
+			if _, ok := chosen[e]; u == v || ok {
+				continue
+			}
+			chosen[e] = struct{}{}
+			edges = append(edges, e)
+		}
+		return edges
+	}
+	total := lo / 2
+	if uint64(m) > total {
+		panic("invalid argument to GNM")
+	}
+	// Floyd's algorithm for sampling m distinct edge indices
+	chosen := make(map[uint64]struct{}, m)
+	edges := make([]Edge, 0, m)
+	for j := total - uint64(m); j < total; j++ {
+		k := nilsafe.Uint64n(r, j+1)
+		if _, ok := chosen[k]; ok {
+			k = j
+		}
+		chosen[k] = struct{}{}
+		edges = append(edges, edgeAt(k))
+	}
+	return edges
+}
+
+// edgeAt returns k-th edge in the order (0, 1), (0, 2), (1, 2), (0, 3), ...
+func edgeAt(k uint64) Edge {
+	v := uint64((1 + math.Sqrt(1+8*float64(k))) / 2)
+	for v*(v-1)/2 > k {
+		v--
+	}
+	for v*(v+1)/2 <= k {
+		v++
+	}
+	return Edge{int(k - v*(v-1)/2), int(v)}
+}
+
+// BarabasiAlbert returns a Barabási–Albert preferential attachment graph with n vertices.
+// Starting from a star with m+1 vertices, every new vertex is connected to m distinct existing
+// vertices, chosen with probabilities proportional to their degrees. It panics if m < 1 or m >= n.
+func BarabasiAlbert(r *rand.Rand, n int, m int) []Edge {
+	if m < 1 || m >= n {
+		panic("invalid argument to BarabasiAlbert")
+	}
+	edges := make([]Edge, 0, m*(n-m))
+	// every vertex appears in repeated once per incident edge
+	repeated := make([]int, 0, 2*m*(n-m))
+	for v := 1; v <= m; v++ {
+		edges = append(edges, Edge{0, v})
+		repeated = append(repeated, 0, v)
+	}
+	targets := make([]int, 0, m)
+	for v := m + 1; v < n; v++ {
+		targets = targets[:0]
+		for len(targets) < m {
+			u := repeated[nilsafe.Intn(r, len(repeated))]
+			if !contains(targets, u) {
+				targets = append(targets, u)
+			}
+		}
+		for _, u := range targets {
+			edges = append(edges, Edge{u, v})
+			repeated = append(repeated, u, v)
+		}
+	}
+	return edges
+}
+
+// WattsStrogatz returns a Watts–Strogatz small-world graph with n vertices. Starting from a ring
+// lattice where every vertex is connected to k/2 nearest neighbors on each side, every edge
+// is rewired with probability p to a uniformly chosen vertex, avoiding self-loops and multiple edges.
+// It panics if k is odd, k < 0, k >= n or p is outside of [0, 1].
+func WattsStrogatz(r *rand.Rand, n int, k int, p float64) []Edge {
+	if k < 0 || k%2 != 0 || k >= n || !(p >= 0 && p <= 1) {
+		panic("invalid argument to WattsStrogatz")
+	}
+	edges := make([]Edge, 0, n*k/2)
+	adj := make(map[Edge]int, n*k/2) // edge -> index in edges
+	deg := make([]int, n)
+	for j := 1; j <= k/2; j++ {
+		for u := 0; u < n; u++ {
+			e := edge(u, (u+j)%n)
+			adj[e] = len(edges)
+			edges = append(edges, e)
+			deg[u]++
+			deg[(u+j)%n]++
+		}
+	}
+	for j := 1; j <= k/2; j++ {
+		for u := 0; u < n; u++ {
+			if nilsafe.Float64(r) >= p {
+				continue
+			}
+			e := edge(u, (u+j)%n)
+			i, ok := adj[e]
+			if !ok {
+				continue // already rewired away from u
+			}
+			if deg[u] >= n-1 {
+				continue // u is connected to every other vertex
+			}
+			var f Edge
+			for {
+				w := nilsafe.Intn(r, n)
+				f = edge(u, w)
+				if _, dup := adj[f]; w != u && !dup {
+					break
+				}
+			}
+			delete(adj, e)
+			adj[f] = i
+			edges[i] = f
+			deg[e.U+e.V-u]--
+			deg[f.U+f.V-u]++
+		}
+	}
+	return edges
+}
+
+// Regular returns a uniformly distributed pseudo-random d-regular graph with n vertices.
+// Generation time grows exponentially with the square of min(d, n-1-d), making Regular
+// practical only for graphs of small degree (or their complements).
+// It panics if d < 0, d >= n or n*d is odd.
+func Regular(r *rand.Rand, n int, d int) []Edge {
+	if d < 0 || d >= n || n*d%2 != 0 {
+		panic("invalid argument to Regular")
+	}
+	if 2*d > n-1 {
+		// complement of a uniform (n-1-d)-regular graph is a uniform d-regular graph
+		present := make(map[Edge]struct{}, n*(n-1-d)/2)
+		for _, e := range Regular(r, n, n-1-d) {
+			present[e] = struct{}{}
+		}
+		edges := make([]Edge, 0, n*d/2)
+		for v := 1; v < n; v++ {
+			for u := 0; u < v; u++ {
+				if _, ok := present[Edge{U: u, V: v}]; !ok {
+					edges = append(edges, Edge{U: u, V: v})
+				}
+			}
+		}
+		return edges
+	}
+	if d == 0 {
+		return []Edge{}
+	}
+	for {
+		if edges := tryRegular(r, n, d); edges != nil {
+			return edges
+		}
+	}
+}
+
+// tryRegular builds a uniformly distributed pairing of n*d stubs (the configuration model),
+// giving up as soon as the pairing has a self-loop or a multiple edge. Every simple d-regular
+// graph corresponds to the same number of pairings, so the graph is uniform when tryRegular succeeds.
+func tryRegular(r *rand.Rand, n int, d int) []Edge {
+	edges := make([]Edge, 0, n*d/2)
+	present := make(map[Edge]struct{}, n*d/2)
+	stubs := make([]int, 0, n*d)
+	for u := 0; u < n; u++ {
+		for i := 0; i < d; i++ {
+			stubs = append(stubs, u)
+		}
+	}
+	for i := 0; i < len(stubs); i += 2 {
+		j := i + 1 + nilsafe.Intn(r, len(stubs)-i-1)
+		stubs[i+1], stubs[j] = stubs[j], stubs[i+1]
+		u, v := stubs[i], stubs[i+1]
+		e := edge(u, v)
+		if _, dup := present[e]; u == v || dup {
+			return nil
+		}
+		present[e] = struct{}{}
+		edges = append(edges, e)
+	}
+	return edges
+}
+
+// SpanningTree returns a uniformly distributed pseudo-random spanning tree of a connected graph
+// with n vertices, given as a list of adjacent vertices for every vertex. When adj is nil,
+// SpanningTree returns a spanning tree of the complete graph with n vertices.
+// It panics if n < 1 or the graph is not connected.
+func SpanningTree(r *rand.Rand, n int, adj [][]int) []Edge {
+	if n < 1 || (adj != nil && (len(adj) != n || !connected(n, adj))) {
+		panic("invalid argument to SpanningTree")
+	}
+	// "Generating random spanning trees more quickly than the cover time" by David Bruce Wilson,
+	// https://doi.org/10.1145/237814.237880: loop-erased random walks from every vertex to the tree
+	inTree := make([]bool, n)
+	next := make([]int, n)
+	inTree[nilsafe.Intn(r, n)] = true
+	edges := make([]Edge, 0, n-1)
+	for i := 0; i < n; i++ {
+		for u := i; !inTree[u]; u = next[u] {
+			next[u] = neighbor(r, n, adj, u)
+		}
+		for u := i; !inTree[u]; u = next[u] {
+			inTree[u] = true
+			edges = append(edges, edge(u, next[u]))
+		}
+	}
+	return edges
+}
+
+func neighbor(r *rand.Rand, n int, adj [][]int, u int) int {
+	if adj == nil {
+		v := nilsafe.Intn(r, n-1)
+		if v >= u {
+			v++
+		}
+		return v
+	}
+	if len(adj[u]) == 0 {
+		panic("invalid argument to SpanningTree")
+	}
+	return adj[u][nilsafe.Intn(r, len(adj[u]))]
+}
+
+// connected reports whether every vertex is reachable from vertex 0.
+func connected(n int, adj [][]int) bool {
+	seen := make([]bool, n)
+	seen[0] = true
+	queue := []int{0}
+	for len(queue) > 0 {
+		v := queue[0]
+		queue = queue[1:]
+		for _, w := range adj[v] {
+			if !seen[w] {
+				seen[w] = true
+				queue = append(queue, w)
+			}
+		}
+	}
+	for _, ok := range seen {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(s []int, u int) bool {
+	for _, v := range s {
+		if v == u {
+			return true
+		}
+	}
+	return false
+}
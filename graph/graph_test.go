@@ -0,0 +1,305 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package graph_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rand/graph"
+	"pgregory.net/rapid"
+	"strconv"
+	"testing"
+)
+
+// checkSimple checks that edges form a simple graph with n vertices and returns vertex degrees.
+func checkSimple(t *rapid.T, n int, edges []graph.Edge) []int {
+	t.Helper()
+	deg := make([]int, n)
+	seen := map[graph.Edge]bool{}
+	for _, e := range edges {
+		if e.U < 0 || e.U >= e.V || e.V >= n {
+			t.Fatalf("invalid edge %v for %v vertices", e, n)
+		}
+		if seen[e] {
+			t.Fatalf("duplicate edge %v", e)
+		}
+		seen[e] = true
+		deg[e.U]++
+		deg[e.V]++
+	}
+	return deg
+}
+
+func checkConnected(t *rapid.T, n int, edges []graph.Edge) {
+	t.Helper()
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(u int) int {
+		if parent[u] != u {
+			parent[u] = find(parent[u])
+		}
+		return parent[u]
+	}
+	components := n
+	for _, e := range edges {
+		if u, v := find(e.U), find(e.V); u != v {
+			parent[u] = v
+			components--
+		}
+	}
+	if components != 1 {
+		t.Fatalf("got %v components for %v vertices", components, n)
+	}
+}
+
+func TestGNP(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(0, 100).Draw(t, "n").(int)
+		p := rapid.Float64Range(0, 1).Draw(t, "p").(float64)
+		edges := graph.GNP(r, n, p)
+		checkSimple(t, n, edges)
+		if p == 1 && len(edges) != n*(n-1)/2 {
+			t.Fatalf("got %v edges for complete graph with %v vertices", len(edges), n)
+		}
+	})
+}
+
+func TestGNP_EdgeFrequency(t *testing.T) {
+	const n, p, iters = 10, 0.3, 20000
+	r := rand.New(1)
+	counts := map[graph.Edge]int{}
+	for i := 0; i < iters; i++ {
+		for _, e := range graph.GNP(r, n, p) {
+			counts[e]++
+		}
+	}
+	stddev := math.Sqrt(iters * p * (1 - p))
+	for v := 1; v < n; v++ {
+		for u := 0; u < v; u++ {
+			if c := counts[graph.Edge{U: u, V: v}]; math.Abs(float64(c)-iters*p) > 5*stddev {
+				t.Errorf("edge (%v, %v) present %v times out of %v, expected %v", u, v, c, iters, iters*p)
+			}
+		}
+	}
+}
+
+func TestGNM(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(0, 100).Draw(t, "n").(int)
+		m := rapid.IntRange(0, n*(n-1)/2).Draw(t, "m").(int)
+		edges := graph.GNM(r, n, m)
+		checkSimple(t, n, edges)
+		if len(edges) != m {
+			t.Fatalf("got %v edges instead of %v", len(edges), m)
+		}
+	})
+}
+
+func TestGNM_Uniform(t *testing.T) {
+	// 4 vertices, 2 edges out of 6: all 15 graphs are equally likely
+	const iters = 150000
+	r := rand.New(1)
+	counts := map[[2]graph.Edge]int{}
+	for i := 0; i < iters; i++ {
+		edges := graph.GNM(r, 4, 2)
+		if edges[1].V < edges[0].V || (edges[1].V == edges[0].V && edges[1].U < edges[0].U) {
+			edges[0], edges[1] = edges[1], edges[0]
+		}
+		counts[[2]graph.Edge{edges[0], edges[1]}]++
+	}
+	if len(counts) != 15 {
+		t.Fatalf("got %v distinct graphs instead of 15", len(counts))
+	}
+	for g, c := range counts {
+		if math.Abs(float64(c)-iters/15) > 5*math.Sqrt(iters/15) {
+			t.Errorf("graph %v generated %v times out of %v", g, c, iters)
+		}
+	}
+}
+
+func TestGNM_Huge(t *testing.T) {
+	if strconv.IntSize < 64 {
+		t.Skip("needs 64-bit int")
+	}
+	shift := 33
+	n := 1 << shift
+	edges := graph.GNM(rand.New(1), n, 100)
+	if len(edges) != 100 {
+		t.Fatalf("got %v edges instead of 100", len(edges))
+	}
+	seen := map[graph.Edge]bool{}
+	for _, e := range edges {
+		if e.U < 0 || e.U >= e.V || e.V >= n || seen[e] {
+			t.Fatalf("invalid edge %v", e)
+		}
+		seen[e] = true
+	}
+}
+
+func TestBarabasiAlbert(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(2, 200).Draw(t, "n").(int)
+		m := rapid.IntRange(1, n-1).Draw(t, "m").(int)
+		edges := graph.BarabasiAlbert(r, n, m)
+		deg := checkSimple(t, n, edges)
+		if len(edges) != m*(n-m) {
+			t.Fatalf("got %v edges instead of %v", len(edges), m*(n-m))
+		}
+		for v, d := range deg[m+1:] {
+			if d < m {
+				t.Fatalf("vertex %v has degree %v < %v", m+1+v, d, m)
+			}
+		}
+		checkConnected(t, n, edges)
+	})
+}
+
+func TestWattsStrogatz(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(1, 100).Draw(t, "n").(int)
+		k := 2 * rapid.IntRange(0, (n-1)/2).Draw(t, "k").(int)
+		p := rapid.Float64Range(0, 1).Draw(t, "p").(float64)
+		edges := graph.WattsStrogatz(r, n, k, p)
+		deg := checkSimple(t, n, edges)
+		if len(edges) != n*k/2 {
+			t.Fatalf("got %v edges instead of %v", len(edges), n*k/2)
+		}
+		if p == 0 {
+			for v, d := range deg {
+				if d != k {
+					t.Fatalf("vertex %v of ring lattice has degree %v instead of %v", v, d, k)
+				}
+			}
+		}
+	})
+}
+
+func TestRegular(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(1, 50).Draw(t, "n").(int)
+		d := rapid.IntRange(0, 4).Draw(t, "d").(int)
+		if d > n-1 {
+			d = n - 1
+		}
+		if rapid.Bool().Draw(t, "complement").(bool) {
+			d = n - 1 - d
+		}
+		if n*d%2 != 0 {
+			d--
+		}
+		edges := graph.Regular(r, n, d)
+		for v, dv := range checkSimple(t, n, edges) {
+			if dv != d {
+				t.Fatalf("vertex %v has degree %v instead of %v", v, dv, d)
+			}
+		}
+	})
+}
+
+func TestRegular_Uniform(t *testing.T) {
+	// 6 vertices: 70 2-regular graphs (and their complements, 3-regular)
+	const iters = 70000
+	r := rand.New(1)
+	for _, d := range []int{2, 3} {
+		counts := map[int]int{}
+		for i := 0; i < iters; i++ {
+			mask := 0
+			for _, e := range graph.Regular(r, 6, d) {
+				mask |= 1 << (e.U*6 + e.V)
+			}
+			counts[mask]++
+		}
+		if len(counts) != 70 {
+			t.Fatalf("got %v distinct %v-regular graphs instead of 70", len(counts), d)
+		}
+		for g, c := range counts {
+			if math.Abs(float64(c)-iters/70) > 5*math.Sqrt(iters/70) {
+				t.Errorf("%v-regular graph %b generated %v times out of %v", d, g, c, iters)
+			}
+		}
+	}
+}
+
+func TestSpanningTree(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(1, 50).Draw(t, "n").(int)
+		var adj [][]int
+		if rapid.Bool().Draw(t, "adj").(bool) {
+			// path graph with extra random chords
+			adj = make([][]int, n)
+			for _, e := range append(graph.GNP(r, n, 0.1), pathEdges(n)...) {
+				adj[e.U] = append(adj[e.U], e.V)
+				adj[e.V] = append(adj[e.V], e.U)
+			}
+		}
+		edges := graph.SpanningTree(r, n, adj)
+		checkSimple(t, n, edges)
+		if len(edges) != n-1 {
+			t.Fatalf("got %v edges instead of %v", len(edges), n-1)
+		}
+		checkConnected(t, n, edges)
+	})
+}
+
+func TestSpanningTree_LongPath(t *testing.T) {
+	const n = 4000
+	adj := make([][]int, n)
+	for _, e := range pathEdges(n) {
+		adj[e.U] = append(adj[e.U], e.V)
+		adj[e.V] = append(adj[e.V], e.U)
+	}
+	edges := graph.SpanningTree(rand.New(1), n, adj)
+	if len(edges) != n-1 {
+		t.Fatalf("got %v edges instead of %v", len(edges), n-1)
+	}
+}
+
+func pathEdges(n int) []graph.Edge {
+	var edges []graph.Edge
+	for v := 1; v < n; v++ {
+		edges = append(edges, graph.Edge{U: v - 1, V: v})
+	}
+	return edges
+}
+
+func TestSpanningTree_Uniform(t *testing.T) {
+	// Cayley's formula: complete graph on 4 vertices has 16 spanning trees
+	const iters = 160000
+	r := rand.New(1)
+	counts := map[int]int{}
+	for i := 0; i < iters; i++ {
+		mask := 0
+		for _, e := range graph.SpanningTree(r, 4, nil) {
+			mask |= 1 << (e.U*4 + e.V)
+		}
+		counts[mask]++
+	}
+	if len(counts) != 16 {
+		t.Fatalf("got %v distinct trees instead of 16", len(counts))
+	}
+	for tree, c := range counts {
+		if math.Abs(float64(c)-iters/16) > 5*math.Sqrt(iters/16) {
+			t.Errorf("tree %b generated %v times out of %v", tree, c, iters)
+		}
+	}
+}
+
+func BenchmarkGNP(b *testing.B) {
+	r := rand.New(1)
+	for i := 0; i < b.N; i++ {
+		graph.GNP(r, 10000, 0.001)
+	}
+}
@@ -0,0 +1,355 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package golden generates the corpus of golden outputs of [rand.Rand] methods,
+// for verifying ports of the package to other languages.
+//
+// Every case of the corpus describes a generator created with rand.New(seed...), a method
+// with its arguments, and results of calling the method on the fresh generator several times
+// in a row. In JSON, integers are encoded as decimal strings (to avoid precision loss
+// in parsers that use float64 for all numbers), finite floats as numbers, infinities as "+Inf"
+// and "-Inf", byte slices as hex strings, big numbers as exact decimal strings, durations
+// as nanoseconds and times in RFC 3339 format.
+// Results of Int and Intn are for platforms with 64-bit int.
+//
+// Cases are either exact or inexact. Outputs of exact cases are reproducible bit for bit
+// on every platform. Float outputs of inexact cases depend on the implementation of functions
+// like math.Exp or math.Log, and on whether the compiler fuses multiplication and addition,
+// so they should be compared with a relative tolerance (see [Equal]).
+package golden
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"pgregory.net/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	callsPerCase = 8
+	rangeSeeds   = 64
+
+	// Tolerance is the maximum relative difference of float outputs of inexact cases.
+	Tolerance = 1e-9
+)
+
+// Case is a single entry of the corpus.
+type Case struct {
+	Seed    []string      `json:"seed"`
+	Method  string        `json:"method"`
+	Exact   bool          `json:"exact"`
+	Args    []interface{} `json:"args"`
+	Outputs []interface{} `json:"outputs"`
+}
+
+// Skipped lists methods that are not covered by the corpus: Seed(s) is equivalent to New(s),
+// and UnmarshalBinary is the inverse of MarshalBinary.
+var Skipped = []string{"Seed", "UnmarshalBinary"}
+
+// inexact lists methods with outputs computed using transcendental functions or fusable floating-point operations.
+var inexact = map[string]bool{
+	"Cauchy":           true,
+	"Dirichlet":        true,
+	"ExpFloat32":       true,
+	"ExpFloat64":       true,
+	"Levy":             true,
+	"LogNormal":        true,
+	"NormFloat32":      true,
+	"NormFloat64":      true,
+	"Pareto":           true,
+	"TruncExpFloat64":  true,
+	"TruncNormFloat64": true,
+	"Weibull":          true,
+}
+
+// seeds returns seeds 0 to rangeSeeds-1, followed by edge cases.
+func seeds() [][]uint64 {
+	var s [][]uint64
+	for i := uint64(0); i < rangeSeeds; i++ {
+		s = append(s, []uint64{i})
+	}
+	return append(s, []uint64{0xdeadbeef}, []uint64{1 << 63}, []uint64{math.MaxUint64}, []uint64{1, 2}, []uint64{1, 2, 3}, []uint64{math.MaxUint64, math.MaxUint64, math.MaxUint64})
+}
+
+type method struct {
+	name string
+	args [][]interface{}
+	call func(r *rand.Rand, args []interface{}) interface{}
+}
+
+var (
+	epoch = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	methods = []method{
+		{"BigFloat", [][]interface{}{{uint(1)}, {uint(53)}, {uint(100)}}, func(r *rand.Rand, a []interface{}) interface{} {
+			prec := a[0].(uint)
+			return strings.TrimSuffix(strings.TrimRight(r.BigFloat(prec).Text('f', int(prec)), "0"), ".")
+		}},
+		{"BigIntn", [][]interface{}{{big.NewInt(10)}, {new(big.Int).Lsh(big.NewInt(1), 64)}, {new(big.Int).Lsh(big.NewInt(3), 100)}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.BigIntn(a[0].(*big.Int))
+		}},
+		{"Cauchy", [][]interface{}{{0.0, 1.0}, {-3.0, 0.5}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Cauchy(a[0].(float64), a[1].(float64))
+		}},
+		{"Dirichlet", [][]interface{}{{[]float64{1, 1, 1}}, {[]float64{0.1, 2.5}}}, func(r *rand.Rand, a []interface{}) interface{} {
+			alpha := a[0].([]float64)
+			dst := make([]float64, len(alpha))
+			r.Dirichlet(alpha, dst)
+			return dst
+		}},
+		{"Duration", [][]interface{}{{time.Duration(0), time.Second}, {-time.Hour, time.Duration(math.MaxInt64)}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Duration(a[0].(time.Duration), a[1].(time.Duration))
+		}},
+		{"ExpFloat32", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} { return r.ExpFloat32() }},
+		{"ExpFloat64", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} { return r.ExpFloat64() }},
+		{"Float32", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} { return r.Float32() }},
+		{"Float64", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} { return r.Float64() }},
+		{"Int", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} { return r.Int() }},
+		{"Int31", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} { return r.Int31() }},
+		{"Int31n", [][]interface{}{{int32(1)}, {int32(10)}, {int32(1<<30 + 1)}, {int32(math.MaxInt32)}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Int31n(a[0].(int32))
+		}},
+		{"Int63", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} { return r.Int63() }},
+		{"Int63n", [][]interface{}{{int64(1)}, {int64(10)}, {int64(1<<62 + 1)}, {int64(math.MaxInt64)}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Int63n(a[0].(int64))
+		}},
+		{"Intn", [][]interface{}{{1}, {10}, {1<<31 + 1}, {math.MaxInt64}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Intn(a[0].(int))
+		}},
+		{"InverseCDF", [][]interface{}{{"identity"}}, func(r *rand.Rand, _ []interface{}) interface{} {
+			return r.InverseCDF(func(u float64) float64 { return u })
+		}},
+		{"Levy", [][]interface{}{{0.0, 1.0}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Levy(a[0].(float64), a[1].(float64))
+		}},
+		{"LogNormal", [][]interface{}{{0.0, 1.0}, {1.5, 0.25}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.LogNormal(a[0].(float64), a[1].(float64))
+		}},
+		{"MarshalBinary", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} {
+			r.Uint32() // make buffered 32-bit output part of the state
+			data, _ := r.MarshalBinary()
+			return data
+		}},
+		{"Multinomial", [][]interface{}{{10, []float64{0.2, 0.3, 0.5}}, {50, []float64{0.01, 0.99}}}, func(r *rand.Rand, a []interface{}) interface{} {
+			p := a[1].([]float64)
+			dst := make([]int, len(p))
+			r.Multinomial(a[0].(int), p, dst)
+			return dst
+		}},
+		{"NormFloat32", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} { return r.NormFloat32() }},
+		{"NormFloat64", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} { return r.NormFloat64() }},
+		{"Pareto", [][]interface{}{{1.0, 1.5}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Pareto(a[0].(float64), a[1].(float64))
+		}},
+		{"Perm", [][]interface{}{{0}, {1}, {5}, {16}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Perm(a[0].(int))
+		}},
+		{"PermInto", [][]interface{}{{5}, {16}}, func(r *rand.Rand, a []interface{}) interface{} {
+			dst := make([]int, a[0].(int))
+			r.PermInto(dst)
+			return dst
+		}},
+		{"Read", [][]interface{}{{0}, {7}, {8}, {19}}, func(r *rand.Rand, a []interface{}) interface{} {
+			p := make([]byte, a[0].(int))
+			_, _ = r.Read(p)
+			return p
+		}},
+		{"Shuffle", [][]interface{}{{5}, {16}}, func(r *rand.Rand, a []interface{}) interface{} {
+			s := identity(a[0].(int))
+			r.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+			return s
+		}},
+		{"ShuffleSlice", [][]interface{}{{5}, {16}}, func(r *rand.Rand, a []interface{}) interface{} {
+			s := identity(a[0].(int))
+			rand.ShuffleSlice(r, s)
+			return s
+		}},
+		{"Time", [][]interface{}{{epoch, epoch.Add(time.Hour)}, {time.Unix(0, 0).UTC(), time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Time(a[0].(time.Time), a[1].(time.Time))
+		}},
+		{"TruncExpFloat64", [][]interface{}{{1.0, 0.5, 2.0}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.TruncExpFloat64(a[0].(float64), a[1].(float64), a[2].(float64))
+		}},
+		{"TruncNormFloat64", [][]interface{}{{0.0, 1.0, -1.0, 1.0}, {0.0, 1.0, 3.0, math.Inf(1)}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.TruncNormFloat64(a[0].(float64), a[1].(float64), a[2].(float64), a[3].(float64))
+		}},
+		{"Uint128n", [][]interface{}{{uint64(0), uint64(10)}, {uint64(1), uint64(0)}, {uint64(math.MaxUint64), uint64(math.MaxUint64)}}, func(r *rand.Rand, a []interface{}) interface{} {
+			hi, lo := r.Uint128n(a[0].(uint64), a[1].(uint64))
+			return []uint64{hi, lo}
+		}},
+		{"Uint32", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} { return r.Uint32() }},
+		{"Uint32n", [][]interface{}{{uint32(0)}, {uint32(10)}, {uint32(1<<31 + 1)}, {uint32(math.MaxUint32)}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Uint32n(a[0].(uint32))
+		}},
+		{"Uint64", [][]interface{}{{}}, func(r *rand.Rand, _ []interface{}) interface{} { return r.Uint64() }},
+		{"Uint64n", [][]interface{}{{uint64(0)}, {uint64(10)}, {uint64(1<<63 + 1)}, {uint64(math.MaxUint64)}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Uint64n(a[0].(uint64))
+		}},
+		{"Weibull", [][]interface{}{{1.5, 2.0}}, func(r *rand.Rand, a []interface{}) interface{} {
+			return r.Weibull(a[0].(float64), a[1].(float64))
+		}},
+	}
+)
+
+func identity(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+// Methods returns names of all methods covered by the corpus.
+func Methods() []string {
+	names := make([]string, len(methods))
+	for i, m := range methods {
+		names[i] = m.name
+	}
+	return names
+}
+
+// Generate returns the corpus.
+func Generate() []Case {
+	var cases []Case
+	for _, seed := range seeds() {
+		for _, m := range methods {
+			for _, args := range m.args {
+				r := rand.New(seed...)
+				c := Case{Method: m.name, Exact: !inexact[m.name], Args: []interface{}{}}
+				for _, s := range seed {
+					c.Seed = append(c.Seed, strconv.FormatUint(s, 10))
+				}
+				for _, a := range args {
+					c.Args = append(c.Args, encode(a))
+				}
+				for i := 0; i < callsPerCase; i++ {
+					c.Outputs = append(c.Outputs, encode(m.call(r, args)))
+				}
+				cases = append(cases, c)
+			}
+		}
+	}
+	return cases
+}
+
+// Marshal returns the JSON encoding of cases, one case per line.
+func Marshal(cases []Case) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("[\n")
+	for i, c := range cases {
+		data, err := json.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+		if i < len(cases)-1 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('\n')
+	}
+	buf.WriteString("]\n")
+	return buf.Bytes(), nil
+}
+
+// Unmarshal parses the JSON encoding of cases.
+func Unmarshal(data []byte) ([]Case, error) {
+	var cases []Case
+	err := json.Unmarshal(data, &cases)
+	return cases, err
+}
+
+// Equal reports whether got matches want, as parsed by [Unmarshal]. Outputs of exact cases
+// must be equal, and float outputs of inexact cases must be equal up to [Tolerance].
+func Equal(want Case, got Case) bool {
+	if !reflect.DeepEqual(want.Seed, got.Seed) || want.Method != got.Method || want.Exact != got.Exact || !reflect.DeepEqual(want.Args, got.Args) {
+		return false
+	}
+	if want.Exact {
+		return reflect.DeepEqual(want.Outputs, got.Outputs)
+	}
+	return approxEqual(want.Outputs, got.Outputs)
+}
+
+func approxEqual(want interface{}, got interface{}) bool {
+	switch w := want.(type) {
+	case float64:
+		g, ok := got.(float64)
+		return ok && (w == g || math.Abs(w-g) <= Tolerance*math.Max(math.Abs(w), math.Abs(g)))
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(w) != len(g) {
+			return false
+		}
+		for i := range w {
+			if !approxEqual(w[i], g[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(want, got)
+	}
+}
+
+func encode(v interface{}) interface{} {
+	switch v := v.(type) {
+	case int:
+		return strconv.FormatInt(int64(v), 10)
+	case int32:
+		return strconv.FormatInt(int64(v), 10)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case float32:
+		if math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
+			return fmt.Sprint(v)
+		}
+		return v
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return fmt.Sprint(v)
+		}
+		return v
+	case string:
+		return v
+	case []byte:
+		return hex.EncodeToString(v)
+	case *big.Int:
+		return v.String()
+	case time.Duration:
+		return strconv.FormatInt(int64(v), 10)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []int:
+		return encodeSlice(len(v), func(i int) interface{} { return v[i] })
+	case []uint64:
+		return encodeSlice(len(v), func(i int) interface{} { return v[i] })
+	case []float64:
+		return encodeSlice(len(v), func(i int) interface{} { return v[i] })
+	default:
+		panic("unsupported type")
+	}
+}
+
+func encodeSlice(n int, at func(int) interface{}) interface{} {
+	s := make([]interface{}, n)
+	for i := range s {
+		s[i] = encode(at(i))
+	}
+	return s
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package nilsafe implements [rand.Rand] methods that accept a nil generator, falling back
+// to the top-level functions (non-deterministic goroutine-local pseudo-random data source),
+// for packages that document nil *rand.Rand arguments as valid.
+package nilsafe
+
+import (
+	"pgregory.net/rand"
+)
+
+// Float64 is [rand.Rand.Float64], or [rand.Float64] when r is nil.
+func Float64(r *rand.Rand) float64 {
+	if r == nil {
+		return rand.Float64()
+	}
+	return r.Float64()
+}
+
+// NormFloat64 is [rand.Rand.NormFloat64], or [rand.NormFloat64] when r is nil.
+func NormFloat64(r *rand.Rand) float64 {
+	if r == nil {
+		return rand.NormFloat64()
+	}
+	return r.NormFloat64()
+}
+
+// ExpFloat64 is [rand.Rand.ExpFloat64], or [rand.ExpFloat64] when r is nil.
+func ExpFloat64(r *rand.Rand) float64 {
+	if r == nil {
+		return rand.ExpFloat64()
+	}
+	return r.ExpFloat64()
+}
+
+// Intn is [rand.Rand.Intn], or [rand.Intn] when r is nil.
+func Intn(r *rand.Rand, n int) int {
+	if r == nil {
+		return rand.Intn(n)
+	}
+	return r.Intn(n)
+}
+
+// Uint64n is [rand.Rand.Uint64n], or [rand.Uint64n] when r is nil.
+func Uint64n(r *rand.Rand, n uint64) uint64 {
+	if r == nil {
+		return rand.Uint64n(n)
+	}
+	return r.Uint64n(n)
+}
@@ -0,0 +1,128 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package sources implements construction of pseudo-random generators by name,
+// for command-line tools that compare this package with alternatives.
+package sources
+
+import (
+	"fmt"
+	"github.com/valyala/fastrand"
+	exprand "golang.org/x/exp/rand"
+	"math"
+	"math/bits"
+	mathrand "math/rand"
+	"pgregory.net/rand"
+)
+
+// Names lists generators supported by [New]:
+//   - rand: [rand.Rand]
+//   - rand-g: top-level functions of this package
+//   - std: math/rand
+//   - x: golang.org/x/exp/rand
+//   - x-wy: golang.org/x/exp/rand with wyrand source
+//   - x-rand-g: golang.org/x/exp/rand with source based on [rand.Intn]
+//   - x-fast: golang.org/x/exp/rand with github.com/valyala/fastrand source
+//   - x-splitmix: golang.org/x/exp/rand with splitmix64 source
+var Names = []string{"rand", "rand-g", "std", "x", "x-wy", "x-rand-g", "x-fast", "x-splitmix"}
+
+// Gen is the subset of generator methods used by tools.
+type Gen interface {
+	Uint64() uint64
+	Float64() float64
+	NormFloat64() float64
+	ExpFloat64() float64
+}
+
+type wyrandSource struct {
+	seed uint64
+}
+
+func (s *wyrandSource) Seed(seed uint64) {
+	s.seed = seed // bad idea
+}
+
+func (s *wyrandSource) Uint64() uint64 {
+	s.seed += 0xa0761d6478bd642f
+	hi, lo := bits.Mul64(s.seed, s.seed^0xe7037ed1a0b428db)
+	return hi ^ lo
+}
+
+type splitmixSource struct {
+	seed uint64
+}
+
+func (s *splitmixSource) Seed(seed uint64) {
+	s.seed = seed
+}
+
+func (s *splitmixSource) Uint64() uint64 {
+	s.seed += 0x9e3779b97f4a7c15
+	z := s.seed
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+type globalSource struct{}
+
+func (s globalSource) Seed(_ uint64) {}
+
+func (s globalSource) Uint64() uint64 {
+	a := rand.Intn(math.MaxUint32)
+	b := rand.Intn(math.MaxUint32)
+	return uint64(a)<<32 | uint64(b)
+}
+
+type fastSource struct {
+	rng fastrand.RNG
+}
+
+func (s *fastSource) Seed(seed uint64) {
+	s.rng.Seed(uint32(seed))
+}
+
+func (s *fastSource) Uint64() uint64 {
+	a := s.rng.Uint32()
+	b := s.rng.Uint32()
+	return uint64(a)<<32 | uint64(b)
+}
+
+type topLevel struct{}
+
+func (topLevel) Uint64() uint64       { return rand.Uint64() }
+func (topLevel) Float64() float64     { return rand.Float64() }
+func (topLevel) NormFloat64() float64 { return rand.NormFloat64() }
+func (topLevel) ExpFloat64() float64  { return rand.ExpFloat64() }
+
+// New returns a constructor of a generator with the specified name, see [Names].
+// Some generators ignore the seed, using global pseudo-random state instead.
+func New(name string) (func(seed uint64) Gen, error) {
+	switch name {
+	case "rand":
+		return func(s uint64) Gen { return rand.New(s) }, nil
+	case "rand-g":
+		return func(_ uint64) Gen { return topLevel{} }, nil
+	case "std":
+		return func(s uint64) Gen { return mathrand.New(mathrand.NewSource(int64(s))) }, nil
+	case "x":
+		return func(s uint64) Gen { return exprand.New(exprand.NewSource(s)) }, nil
+	case "x-wy":
+		return func(s uint64) Gen { return exprand.New(&wyrandSource{s}) }, nil
+	case "x-rand-g":
+		return func(_ uint64) Gen { return exprand.New(globalSource{}) }, nil
+	case "x-fast":
+		return func(s uint64) Gen {
+			var rng fastrand.RNG
+			rng.Seed(uint32(s))
+			return exprand.New(&fastSource{rng})
+		}, nil
+	case "x-splitmix":
+		return func(s uint64) Gen { return exprand.New(&splitmixSource{s}) }, nil
+	default:
+		return nil, fmt.Errorf("unknown RNG: %q", name)
+	}
+}
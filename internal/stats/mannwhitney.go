@@ -0,0 +1,109 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+const mannWhitneyExactMax = 50
+
+// MannWhitneyU returns the Mann–Whitney U statistic of x (the number of pairs
+// with x[i] > y[j], ties counting as 1/2) and the two-sided p-value of the hypothesis
+// that x and y come from the same distribution. The p-value is exact for small samples
+// without ties, and uses the normal approximation with tie correction otherwise.
+func MannWhitneyU(x []float64, y []float64) (u float64, p float64) {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 0, 1
+	}
+
+	type obs struct {
+		v   float64
+		isX bool
+	}
+	all := make([]obs, 0, n1+n2)
+	for _, v := range x {
+		all = append(all, obs{v, true})
+	}
+	for _, v := range y {
+		all = append(all, obs{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].v < all[j].v })
+
+	n := float64(n1 + n2)
+	r1, tieSum := 0.0, 0.0
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2 // average of 1-based ranks i+1..j
+		for k := i; k < j; k++ {
+			if all[k].isX {
+				r1 += rank
+			}
+		}
+		t := float64(j - i)
+		tieSum += t*t*t - t
+		i = j
+	}
+	u = r1 - float64(n1)*float64(n1+1)/2
+
+	if tieSum == 0 && n1+n2 <= mannWhitneyExactMax {
+		lo, hi := mannWhitneyExact(n1, n2, int(u))
+		return u, math.Min(1, 2*math.Min(lo, hi))
+	}
+
+	mu := float64(n1) * float64(n2) / 2
+	sigma := math.Sqrt(float64(n1) * float64(n2) / 12 * ((n + 1) - tieSum/(n*(n-1))))
+	if sigma == 0 {
+		return u, 1
+	}
+	d := math.Max(0, math.Abs(u-mu)-0.5) // continuity correction
+	return u, NormalTwoSided(d / sigma)
+}
+
+// mannWhitneyExact returns P(U <= u) and P(U >= u) for samples of sizes n1 and n2 without ties.
+func mannWhitneyExact(n1 int, n2 int, u int) (lo float64, hi float64) {
+	// c[i][j][k] is the number of orderings of i x's and j y's with U = k;
+	// the largest element is either x (adding j to U) or y
+	maxU := n1 * n2
+	prev := make([][]float64, n2+1)
+	for j := range prev {
+		prev[j] = make([]float64, maxU+1)
+		prev[j][0] = 1 // no x's
+	}
+	for i := 1; i <= n1; i++ {
+		cur := make([][]float64, n2+1)
+		for j := range cur {
+			cur[j] = make([]float64, maxU+1)
+			for k := 0; k <= i*j; k++ {
+				if k >= j {
+					cur[j][k] += prev[j][k-j]
+				}
+				if j > 0 {
+					cur[j][k] += cur[j-1][k]
+				}
+			}
+		}
+		prev = cur
+	}
+
+	total := 0.0
+	for k, c := range prev[n2] {
+		total += c
+		if k <= u {
+			lo += c
+		}
+		if k >= u {
+			hi += c
+		}
+	}
+	return lo / total, hi / total
+}
@@ -0,0 +1,186 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package stats implements distribution functions used to compute p-values of statistical tests.
+package stats
+
+import (
+	"math"
+)
+
+const (
+	igamEpsilon = 1e-15
+	igamMaxIter = 10000
+)
+
+// NormalSF returns P(Z > z) for a standard normal Z.
+func NormalSF(z float64) float64 {
+	return 0.5 * math.Erfc(z/math.Sqrt2)
+}
+
+// NormalTwoSided returns P(|Z| > |z|) for a standard normal Z.
+func NormalTwoSided(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// ChiSquareSF returns P(X > x) for X with chi-squared distribution with dof degrees of freedom.
+func ChiSquareSF(x float64, dof float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	return GammaQ(dof/2, x/2)
+}
+
+// PoissonCDF returns P(X <= k) for X with Poisson distribution with mean lambda.
+func PoissonCDF(k int, lambda float64) float64 {
+	if k < 0 {
+		return 0
+	}
+	return GammaQ(float64(k)+1, lambda)
+}
+
+// PoissonSF returns P(X >= k) for X with Poisson distribution with mean lambda.
+func PoissonSF(k int, lambda float64) float64 {
+	if k <= 0 {
+		return 1
+	}
+	return GammaP(float64(k), lambda)
+}
+
+// PoissonTwoSided returns the two-sided p-value of observing k for Poisson distribution with mean lambda.
+func PoissonTwoSided(k int, lambda float64) float64 {
+	return math.Min(1, 2*math.Min(PoissonCDF(k, lambda), PoissonSF(k, lambda)))
+}
+
+// GammaP returns the regularized lower incomplete gamma function P(a, x).
+func GammaP(a float64, x float64) float64 {
+	switch {
+	case x <= 0:
+		return 0
+	case x < a+1:
+		return gammaSeries(a, x)
+	default:
+		return 1 - gammaFraction(a, x)
+	}
+}
+
+// GammaQ returns the regularized upper incomplete gamma function Q(a, x) = 1 - P(a, x).
+func GammaQ(a float64, x float64) float64 {
+	switch {
+	case x <= 0:
+		return 1
+	case x < a+1:
+		return 1 - gammaSeries(a, x)
+	default:
+		return gammaFraction(a, x)
+	}
+}
+
+func gammaPrefix(a float64, x float64) float64 {
+	lg, _ := math.Lgamma(a)
+	return math.Exp(a*math.Log(x) - x - lg)
+}
+
+// gammaSeries computes P(a, x) using the power series, which converges quickly for x < a+1.
+func gammaSeries(a float64, x float64) float64 {
+	term := 1 / a
+	sum := term
+	for n := 1; n < igamMaxIter; n++ {
+		term *= x / (a + float64(n))
+		sum += term
+		if term < sum*igamEpsilon {
+			break
+		}
+	}
+	return sum * gammaPrefix(a, x)
+}
+
+// gammaFraction computes Q(a, x) using Lentz's method for the continued fraction, which converges quickly for x >= a+1.
+func gammaFraction(a float64, x float64) float64 {
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < igamMaxIter; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < igamEpsilon {
+			break
+		}
+	}
+	return h * gammaPrefix(a, x)
+}
+
+// KolmogorovSF returns the asymptotic P(√n·D > x) for the Kolmogorov–Smirnov statistic D.
+func KolmogorovSF(x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	if x < 0.2 {
+		return 1 // series converges slowly, and the result is 1 to double precision
+	}
+	sum := 0.0
+	for k := 1; k < 100; k++ {
+		term := math.Exp(-2 * float64(k*k) * x * x)
+		if k%2 == 0 {
+			sum -= term
+		} else {
+			sum += term
+		}
+		if term < 1e-17 {
+			break
+		}
+	}
+	return math.Max(0, math.Min(1, 2*sum))
+}
+
+// AndersonDarlingSF returns P(A² > x) for the Anderson–Darling statistic A² of n samples
+// from a fully specified continuous distribution, using approximation from "Evaluating
+// the Anderson-Darling distribution" by George Marsaglia and John Marsaglia, https://doi.org/10.18637/jss.v009.i02.
+func AndersonDarlingSF(x float64, n int) float64 {
+	if x <= 0 {
+		return 1
+	}
+	p := adInf(x)
+	p += adErrFix(float64(n), p)
+	return math.Max(0, math.Min(1, 1-p))
+}
+
+// adInf returns the asymptotic CDF of the Anderson–Darling statistic.
+func adInf(z float64) float64 {
+	if z < 2 {
+		return math.Exp(-1.2337141/z) / math.Sqrt(z) * (2.00012 + (0.247105-(0.0649821-(0.0347962-(0.011672-0.00168691*z)*z)*z)*z)*z)
+	}
+	return math.Exp(-math.Exp(1.0776 - (2.30695-(0.43424-(0.082433-(0.008056-0.0003146*z)*z)*z)*z)*z))
+}
+
+// adErrFix returns the correction of the asymptotic CDF value x for n samples.
+func adErrFix(n float64, x float64) float64 {
+	if x > 0.8 {
+		return (-130.2137 + (745.2337-(1705.091-(1950.646-(1116.360-255.7844*x)*x)*x)*x)*x) / n
+	}
+	c := 0.01265 + 0.1757/n
+	if x < c {
+		t := x / c
+		t = math.Sqrt(t) * (1 - t) * (49*t - 102)
+		return t * (0.0037/(n*n) + 0.00078/n + 0.00006) / n
+	}
+	x = (x - c) / (0.8 - c)
+	x = -0.00022633 + (6.54034-(14.6538-(14.458-(8.259-1.91864*x)*x)*x)*x)*x
+	return x * (0.04213/n + 0.01365/(n*n))
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistributions(t *testing.T) {
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"NormalSF(1.96)", NormalSF(1.96), 0.024997895148220435},
+		{"NormalTwoSided(-1.96)", NormalTwoSided(-1.96), 0.04999579029644087},
+		{"ChiSquareSF(3.84, 1)", ChiSquareSF(3.841458820694124, 1), 0.05},
+		{"ChiSquareSF(18.307, 10)", ChiSquareSF(18.307038053275146, 10), 0.05},
+		{"ChiSquareSF(1100, 1000)", ChiSquareSF(1100, 1000), 0.014614408126295194},
+		{"PoissonCDF(2, 3)", PoissonCDF(2, 3), 0.42319008112684353},
+		{"PoissonSF(5, 3)", PoissonSF(5, 3), 0.18473675547622792},
+		{"GammaP(0.5, 0.1)", GammaP(0.5, 0.1), math.Erf(math.Sqrt(0.1))},
+		{"GammaQ(1, 50)", GammaQ(1, 50), math.Exp(-50)},
+	}
+	for _, test := range tests {
+		if math.Abs(test.got/test.want-1) > 1e-9 {
+			t.Errorf("%v = %v, want %v", test.name, test.got, test.want)
+		}
+	}
+}
+
+func TestCriticalValues(t *testing.T) {
+	// asymptotic critical values of Kolmogorov–Smirnov and Anderson–Darling statistics
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"KolmogorovSF(1.358)", KolmogorovSF(1.358), 0.05},
+		{"KolmogorovSF(1.628)", KolmogorovSF(1.628), 0.01},
+		{"AndersonDarlingSF(1.933)", AndersonDarlingSF(1.933, 1000000), 0.10},
+		{"AndersonDarlingSF(2.492)", AndersonDarlingSF(2.492, 1000000), 0.05},
+		{"AndersonDarlingSF(3.857)", AndersonDarlingSF(3.857, 1000000), 0.01},
+	}
+	for _, test := range tests {
+		if math.Abs(test.got/test.want-1) > 0.03 {
+			t.Errorf("%v = %v, want %v", test.name, test.got, test.want)
+		}
+	}
+}
+
+func TestMannWhitneyU(t *testing.T) {
+	tests := []struct {
+		name  string
+		x, y  []float64
+		wantU float64
+		wantP float64
+	}{
+		{"separated", []float64{1, 2, 3}, []float64{4, 5, 6}, 0, 0.1},
+		{"separated reversed", []float64{6, 7, 8, 9, 10}, []float64{1, 2, 3, 4, 5}, 25, 2.0 / 252},
+		{"interleaved", []float64{1, 3, 5}, []float64{2, 4, 6}, 3, 0.7},
+		{"identical", []float64{1, 1, 2, 2}, []float64{1, 1, 2, 2}, 8, 1},
+	}
+	for _, test := range tests {
+		u, p := MannWhitneyU(test.x, test.y)
+		if u != test.wantU || math.Abs(p/test.wantP-1) > 1e-9 {
+			t.Errorf("%v: got U = %v, p = %v, want U = %v, p = %v", test.name, u, p, test.wantU, test.wantP)
+		}
+	}
+}
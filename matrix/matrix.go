@@ -0,0 +1,152 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package matrix implements generation of pseudo-random matrices for numerical testing.
+//
+// Matrices are stored in row-major order: element (i, j) of a matrix with n columns
+// is dst[i*n+j].
+//
+// All functions accept a [rand.Rand] parameter. When it is nil, functions use
+// non-deterministic goroutine-local pseudo-random data source, and are safe for concurrent use
+// from multiple goroutines.
+package matrix
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rand/internal/nilsafe"
+)
+
+// Gaussian fills dst with independent standard normally distributed pseudo-random elements.
+func Gaussian(r *rand.Rand, dst []float64) {
+	for i := range dst {
+		dst[i] = nilsafe.NormFloat64(r)
+	}
+}
+
+// Sparse fills dst with elements that are independently non-zero with probability density,
+// non-zero elements being standard normally distributed. It panics if density is outside of [0, 1].
+func Sparse(r *rand.Rand, dst []float64, density float64) {
+	if !(density >= 0 && density <= 1) {
+		panic("invalid argument to Sparse")
+	}
+	for i := range dst {
+		if nilsafe.Float64(r) < density {
+			dst[i] = nilsafe.NormFloat64(r)
+		} else {
+			dst[i] = 0
+		}
+	}
+}
+
+// Orthogonal fills dst with an n×n orthogonal matrix, uniformly distributed
+// with respect to the Haar measure on the orthogonal group O(n). It panics if len(dst) != n*n.
+func Orthogonal(r *rand.Rand, dst []float64, n int) {
+	if n < 0 || len(dst) != n*n {
+		panic("invalid argument to Orthogonal")
+	}
+	orthogonal(r, dst, n)
+}
+
+// SPD fills dst with an n×n symmetric positive-definite matrix Q·Λ·Qᵀ, where Q is a Haar-uniform
+// orthogonal matrix and Λ is diagonal with eigenvalues log-uniformly distributed in [1, cond].
+// When n >= 2, the smallest and the largest eigenvalues are exactly 1 and cond, so that
+// the condition number of the matrix is cond. It panics if len(dst) != n*n or cond < 1.
+func SPD(r *rand.Rand, dst []float64, n int, cond float64) {
+	if n < 0 || len(dst) != n*n || !(cond >= 1) || math.IsInf(cond, 1) {
+		panic("invalid argument to SPD")
+	}
+	eig := make([]float64, n)
+	logCond := math.Log(cond)
+	for i := range eig {
+		switch {
+		case i == 0 && n >= 2:
+			eig[i] = 1
+		case i == 1 && n >= 2:
+			eig[i] = cond
+		default:
+			eig[i] = math.Exp(logCond * nilsafe.Float64(r))
+		}
+	}
+	q := make([]float64, n*n)
+	orthogonal(r, q, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			s := 0.0
+			for k := 0; k < n; k++ {
+				s += q[i*n+k] * eig[k] * q[j*n+k]
+			}
+			dst[i*n+j] = s
+			dst[j*n+i] = s
+		}
+	}
+}
+
+func orthogonal(r *rand.Rand, dst []float64, n int) {
+	// "How to generate random matrices from the classical compact groups" by Francesco Mezzadri,
+	// https://arxiv.org/abs/math-ph/0609050: Q factor of a Gaussian matrix, with columns
+	// multiplied by signs of the diagonal of R to make the decomposition unique
+	a := make([]float64, n*n)
+	Gaussian(r, a)
+	vs := make([][]float64, n)
+	sign := make([]float64, n)
+	for k := 0; k < n; k++ {
+		// Householder reflection H = I - 2·v·vᵀ that zeroes a[k+1:, k]
+		v := make([]float64, n-k)
+		norm := 0.0
+		for i := range v {
+			v[i] = a[(k+i)*n+k]
+			norm += v[i] * v[i]
+		}
+		norm = math.Sqrt(norm)
+		alpha := -math.Copysign(norm, v[0])
+		sign[k] = math.Copysign(1, alpha)
+		v[0] -= alpha
+		vnorm := 0.0
+		for _, x := range v {
+			vnorm += x * x
+		}
+		if vnorm == 0 {
+			continue // column is already zero, with probability 0
+		}
+		vnorm = math.Sqrt(vnorm)
+		for i := range v {
+			v[i] /= vnorm
+		}
+		vs[k] = v
+		householder(a, n, k, v)
+	}
+	for i := range dst {
+		dst[i] = 0
+	}
+	for i := 0; i < n; i++ {
+		dst[i*n+i] = 1
+	}
+	for k := n - 1; k >= 0; k-- {
+		if vs[k] != nil {
+			householder(dst, n, k, vs[k])
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			dst[i*n+j] *= sign[j]
+		}
+	}
+}
+
+// householder applies I - 2·v·vᵀ to the lower-right (n-k)×(n-k) block of a from the left.
+func householder(a []float64, n int, k int, v []float64) {
+	for j := k; j < n; j++ {
+		s := 0.0
+		for i, x := range v {
+			s += x * a[(k+i)*n+j]
+		}
+		s *= 2
+		for i, x := range v {
+			a[(k+i)*n+j] -= s * x
+		}
+	}
+}
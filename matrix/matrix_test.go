@@ -0,0 +1,157 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package matrix_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rand/matrix"
+	"pgregory.net/rand/randtest"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func TestGaussian(t *testing.T) {
+	const n = 100000
+	a := make([]float64, n)
+	matrix.Gaussian(rand.New(1), a)
+	mean, sq := 0.0, 0.0
+	for _, x := range a {
+		mean += x
+		sq += x * x
+	}
+	mean /= n
+	variance := sq/n - mean*mean
+	if math.Abs(mean) > 5/math.Sqrt(n) || math.Abs(variance-1) > 5*math.Sqrt(2.0/n) {
+		t.Errorf("got mean %v and variance %v", mean, variance)
+	}
+}
+
+func TestSparse(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		density := rapid.Float64Range(0, 1).Draw(t, "density").(float64)
+		const n = 10000
+		a := make([]float64, n)
+		for i := range a {
+			a[i] = math.NaN()
+		}
+		matrix.Sparse(r, a, density)
+		nz := 0
+		for _, x := range a {
+			if math.IsNaN(x) {
+				t.Fatalf("element not filled")
+			}
+			if x != 0 {
+				nz++
+			}
+		}
+		stddev := math.Sqrt(n * density * (1 - density))
+		if math.Abs(float64(nz)-n*density) > 6*stddev+1 {
+			t.Fatalf("got %v non-zero elements out of %v for density %v", nz, n, density)
+		}
+	})
+}
+
+func TestOrthogonal(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(0, 20).Draw(t, "n").(int)
+		q := make([]float64, n*n)
+		matrix.Orthogonal(r, q, n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				s := 0.0
+				for k := 0; k < n; k++ {
+					s += q[k*n+i] * q[k*n+j]
+				}
+				want := 0.0
+				if i == j {
+					want = 1
+				}
+				if math.Abs(s-want) > 1e-12 {
+					t.Fatalf("(QᵀQ)[%v][%v] = %v", i, j, s)
+				}
+			}
+		}
+	})
+}
+
+func TestOrthogonal_Haar(t *testing.T) {
+	// rotation angle of a Haar-uniform 2×2 orthogonal matrix is uniform, and both determinants are equally likely
+	const iters, bins = 100000, 20
+	r := rand.New(1)
+	counts := make([]int, bins)
+	reflections := 0
+	q := make([]float64, 4)
+	for i := 0; i < iters; i++ {
+		matrix.Orthogonal(r, q, 2)
+		θ := math.Atan2(q[2], q[0])
+		counts[int((θ+math.Pi)/(2*math.Pi)*bins)%bins]++
+		if q[0]*q[3]-q[1]*q[2] < 0 {
+			reflections++
+		}
+	}
+	probs := make([]float64, bins)
+	for i := range probs {
+		probs[i] = 1.0 / bins
+	}
+	randtest.Check(t, "rotation angle χ²", randtest.ChiSquare(counts, probs), randtest.DefaultAlpha)
+	if math.Abs(float64(reflections)-iters/2) > 5*math.Sqrt(iters/4) {
+		t.Errorf("got %v reflections out of %v", reflections, iters)
+	}
+}
+
+func TestSPD(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(1, 20).Draw(t, "n").(int)
+		cond := rapid.Float64Range(1, 1e6).Draw(t, "cond").(float64)
+		a := make([]float64, n*n)
+		matrix.SPD(r, a, n, cond)
+		for i := 0; i < n; i++ {
+			for j := 0; j < i; j++ {
+				if a[i*n+j] != a[j*n+i] {
+					t.Fatalf("matrix is not symmetric at (%v, %v)", i, j)
+				}
+			}
+		}
+		// eigenvalues are in [1, cond], and so are Rayleigh quotients
+		x := make([]float64, n)
+		for iter := 0; iter < 10; iter++ {
+			for i := range x {
+				x[i] = r.NormFloat64()
+			}
+			num, den := 0.0, 0.0
+			for i := 0; i < n; i++ {
+				den += x[i] * x[i]
+				for j := 0; j < n; j++ {
+					num += x[i] * a[i*n+j] * x[j]
+				}
+			}
+			if q := num / den; q < 1-1e-9 || q > cond*(1+1e-9) {
+				t.Fatalf("Rayleigh quotient %v outside of [1, %v]", q, cond)
+			}
+		}
+		// trace is the sum of eigenvalues, which for n >= 2 includes both 1 and cond
+		tr := 0.0
+		for i := 0; i < n; i++ {
+			tr += a[i*n+i]
+		}
+		if n >= 2 && (tr < 1+cond-1e-9*cond || tr > float64(n-1)*cond+1+1e-9*cond) {
+			t.Fatalf("trace %v is inconsistent with condition number %v", tr, cond)
+		}
+	})
+}
+
+func BenchmarkOrthogonal(b *testing.B) {
+	r := rand.New(1)
+	q := make([]float64, 100*100)
+	for i := 0; i < b.N; i++ {
+		matrix.Orthogonal(r, q, 100)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"pgregory.net/rand/internal/stats"
+	"text/tabwriter"
+)
+
+// change is the difference of a single benchmark between two runs.
+type change struct {
+	key      string
+	old, new float64 // medians
+	delta    float64
+	p        float64
+	verdict  string
+}
+
+// compare matches benchmarks of old and cur runs and classifies the changes: a change
+// is significant if the Mann–Whitney p-value is below alpha and the medians differ
+// by more than threshold (relative).
+func compare(old Run, cur Run, alpha float64, threshold float64) []change {
+	oldByKey := map[string]Benchmark{}
+	for _, b := range old.Benchmarks {
+		oldByKey[b.key()] = b
+	}
+
+	var changes []change
+	for _, nb := range cur.Benchmarks {
+		ob, ok := oldByKey[nb.key()]
+		if !ok {
+			continue
+		}
+		c := change{key: nb.key(), old: ob.median(), new: nb.median()}
+		c.delta = c.new/c.old - 1
+		_, c.p = stats.MannWhitneyU(ob.NsPerOp, nb.NsPerOp)
+		switch {
+		case c.p >= alpha || (c.delta <= threshold && c.delta >= -threshold):
+			c.verdict = "~"
+		case c.delta > 0:
+			c.verdict = "REGRESSION"
+		default:
+			c.verdict = "improvement"
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+func printChanges(w io.Writer, old Run, cur Run, changes []change) error {
+	_, _ = fmt.Fprintf(w, "old: %v (%v, %v)\nnew: %v (%v, %v)\n\n", old.Commit, old.Date.Format("2006-01-02"), old.Go, cur.Commit, cur.Date.Format("2006-01-02"), cur.Go)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(tw, "benchmark\told time/op\tnew time/op\tdelta\tp-value\t\n")
+	for _, c := range changes {
+		_, _ = fmt.Fprintf(tw, "%v\t%.4gns\t%.4gns\t%+.2f%%\t%.3f\t%v\n", c.key, c.old, c.new, 100*c.delta, c.p, c.verdict)
+	}
+	return tw.Flush()
+}
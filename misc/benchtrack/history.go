@@ -0,0 +1,123 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Run is the result of running benchmark suites at a single commit.
+type Run struct {
+	Commit     string      `json:"commit"`
+	Date       time.Time   `json:"date"`
+	Go         string      `json:"go"`
+	GOOS       string      `json:"goos"`
+	GOARCH     string      `json:"goarch"`
+	CPU        string      `json:"cpu"`
+	Benchmarks []Benchmark `json:"benchmarks"`
+}
+
+// Benchmark holds all samples of a single benchmark.
+type Benchmark struct {
+	Suite   string    `json:"suite"`
+	Name    string    `json:"name"`
+	NsPerOp []float64 `json:"ns_per_op"`
+}
+
+func (b Benchmark) key() string {
+	return b.Suite + "/" + b.Name
+}
+
+func (b Benchmark) median() float64 {
+	s := append([]float64(nil), b.NsPerOp...)
+	sort.Float64s(s)
+	if len(s)%2 == 1 {
+		return s[len(s)/2]
+	}
+	return (s[len(s)/2-1] + s[len(s)/2]) / 2
+}
+
+// History is the list of runs, oldest first.
+type History []Run
+
+func readHistory(path string) (History, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var h History
+	err = json.Unmarshal(data, &h)
+	return h, err
+}
+
+func (h History) write(path string) error {
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// add appends run to the history, replacing earlier runs at the same commit
+// (unless the tree was dirty, when there is nothing to identify the code by).
+func (h History) add(run Run) History {
+	if !strings.HasSuffix(run.Commit, "-dirty") {
+		kept := h[:0]
+		for _, r := range h {
+			if r.Commit != run.Commit {
+				kept = append(kept, r)
+			}
+		}
+		h = kept
+	}
+	return append(h, run)
+}
+
+// find returns the latest run at a commit with the given prefix.
+func (h History) find(commit string) (Run, bool) {
+	for i := len(h) - 1; i >= 0; i-- {
+		if strings.HasPrefix(h[i].Commit, commit) {
+			return h[i], true
+		}
+	}
+	return Run{}, false
+}
+
+// writeCSV writes the history with one row per benchmark per run.
+func (h History) writeCSV(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	_ = w.Write([]string{"commit", "date", "go", "goos", "goarch", "cpu", "suite", "benchmark", "samples", "median_ns_per_op"})
+	for _, r := range h {
+		for _, b := range r.Benchmarks {
+			_ = w.Write([]string{
+				r.Commit, r.Date.Format(time.RFC3339), r.Go, r.GOOS, r.GOARCH, r.CPU, b.Suite, b.Name,
+				strconv.Itoa(len(b.NsPerOp)), strconv.FormatFloat(b.median(), 'g', -1, 64),
+			})
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Command benchtrack runs the benchmark suites of the package and tracks results across commits.
+//
+// Suites are selected by build tags: rand (no tag, this package), std (benchstd, math/rand),
+// stdpcg (benchstdpcg, math/rand/v2), x (benchx, golang.org/x/exp/rand) and fast (benchfast).
+// Results of every run are stored in a JSON history file, keyed by the current commit
+// (a later run at the same commit replaces the earlier one), and can be exported as CSV.
+//
+// After the run, benchtrack compares the results with the previous commit in the history
+// (or the one specified with -base) using the Mann–Whitney U test, and exits with status 1
+// if any benchmark became significantly slower. For example:
+//
+//	go run ./misc/benchtrack -history ~/rand-bench.json -bench 'Rand_Intn|ShuffleSlice'
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	var (
+		dir       = flag.String("dir", ".", "directory of the package to benchmark")
+		suites    = flag.String("suites", "rand,std,stdpcg,x", "comma-separated list of benchmark suites to run (rand/std/stdpcg/x/fast)")
+		bench     = flag.String("bench", ".", "regular expression selecting benchmarks to run")
+		count     = flag.Int("count", 10, "number of times to run each benchmark")
+		benchtime = flag.String("benchtime", "", "run time of each benchmark (go test default if not specified)")
+		history   = flag.String("history", "benchtrack.json", "JSON history file")
+		csvPath   = flag.String("csv", "", "file to export the history to as CSV")
+		base      = flag.String("base", "", "commit to compare with (previous commit in the history if not specified)")
+		alpha     = flag.Float64("alpha", 0.05, "significance level")
+		threshold = flag.Float64("threshold", 0.05, "minimum relative change to report")
+		noRun     = flag.Bool("norun", false, "do not run benchmarks, compare the last run in the history instead")
+	)
+	flag.Parse()
+
+	if *count < 1 || *alpha <= 0 || *alpha >= 1 || *threshold < 0 {
+		log.Fatal("invalid -count, -alpha or -threshold")
+	}
+
+	h, err := readHistory(*history)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var run Run
+	if *noRun {
+		if len(h) == 0 {
+			log.Fatal("empty history")
+		}
+		run = h[len(h)-1]
+	} else {
+		run, err = runSuites(*dir, strings.Split(*suites, ","), *bench, *count, *benchtime)
+		if err != nil {
+			log.Fatal(err)
+		}
+		h = h.add(run)
+		if err := h.write(*history); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *csvPath != "" {
+		if err := h.writeCSV(*csvPath); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	var old Run
+	found := false
+	if *base != "" {
+		old, found = h.find(*base)
+	} else {
+		for i := len(h) - 1; i >= 0 && !found; i-- {
+			if h[i].Commit != run.Commit {
+				old, found = h[i], true
+			}
+		}
+	}
+	if !found {
+		fmt.Fprintf(os.Stderr, "benchtrack: no run to compare with\n")
+		return
+	}
+
+	changes := compare(old, run, *alpha, *threshold)
+	if err := printChanges(os.Stdout, old, run, changes); err != nil {
+		log.Fatal(err)
+	}
+	for _, c := range changes {
+		if c.verdict == "REGRESSION" {
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// suiteTags maps suite names to the build tags selecting them.
+var suiteTags = map[string]string{
+	"rand":   "",
+	"std":    "benchstd",
+	"stdpcg": "benchstdpcg",
+	"x":      "benchx",
+	"fast":   "benchfast",
+}
+
+// runSuites runs the benchmark suites in dir and collects the results.
+func runSuites(dir string, suites []string, bench string, count int, benchtime string) (Run, error) {
+	commit, err := gitCommit(dir)
+	if err != nil {
+		return Run{}, err
+	}
+	goVersion, err := output(dir, "go", "env", "GOVERSION")
+	if err != nil {
+		return Run{}, err
+	}
+	run := Run{
+		Commit: commit,
+		Date:   time.Now().UTC().Truncate(time.Second),
+		Go:     goVersion,
+	}
+
+	for _, suite := range suites {
+		tag, ok := suiteTags[suite]
+		if !ok {
+			return Run{}, fmt.Errorf("unknown suite %q", suite)
+		}
+		args := []string{"test", "-run=^$", "-bench=" + bench, "-count=" + strconv.Itoa(count)}
+		if tag != "" {
+			args = append(args, "-tags="+tag)
+		}
+		if benchtime != "" {
+			args = append(args, "-benchtime="+benchtime)
+		}
+		fmt.Fprintf(os.Stderr, "benchtrack: running suite %v: go %v\n", suite, strings.Join(args, " "))
+		out, err := output(dir, "go", args...)
+		if err != nil {
+			return Run{}, fmt.Errorf("suite %v: %w", suite, err)
+		}
+		parseBenchOutput(&run, suite, out)
+	}
+	return run, nil
+}
+
+// parseBenchOutput adds results from the output of go test -bench to run.
+func parseBenchOutput(run *Run, suite string, out string) {
+	index := map[string]int{}
+	s := bufio.NewScanner(strings.NewReader(out))
+	for s.Scan() {
+		line := s.Text()
+		if k, v, ok := strings.Cut(line, ": "); ok {
+			switch k {
+			case "goos":
+				run.GOOS = v
+			case "goarch":
+				run.GOARCH = v
+			case "cpu":
+				run.CPU = v
+			}
+			continue
+		}
+
+		// BenchmarkName-8   	123456	        4.56 ns/op
+		f := strings.Fields(line)
+		if len(f) < 4 || !strings.HasPrefix(f[0], "Benchmark") || f[3] != "ns/op" {
+			continue
+		}
+		ns, err := strconv.ParseFloat(f[2], 64)
+		if err != nil {
+			continue
+		}
+		name := strings.TrimPrefix(f[0], "Benchmark")
+		if i := strings.LastIndexByte(name, '-'); i > 0 {
+			if _, err := strconv.Atoi(name[i+1:]); err == nil {
+				name = name[:i] // GOMAXPROCS suffix
+			}
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(run.Benchmarks)
+			index[name] = i
+			run.Benchmarks = append(run.Benchmarks, Benchmark{Suite: suite, Name: name})
+		}
+		run.Benchmarks[i].NsPerOp = append(run.Benchmarks[i].NsPerOp, ns)
+	}
+}
+
+// gitCommit returns the current commit of dir, with "-dirty" suffix if there are uncommitted changes.
+func gitCommit(dir string) (string, error) {
+	commit, err := output(dir, "git", "rev-parse", "--short=12", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	status, err := output(dir, "git", "status", "--porcelain", "--untracked-files=no")
+	if err != nil {
+		return "", err
+	}
+	if status != "" {
+		commit += "-dirty"
+	}
+	return commit, nil
+}
+
+func output(dir string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%v %v: %w\n%s%s", name, strings.Join(args, " "), err, out, stderr.Bytes())
+	}
+	return strings.TrimSpace(string(out)), nil
+}
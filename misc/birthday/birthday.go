@@ -0,0 +1,83 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Command birthday runs the birthday (collisions) test against several generators,
+// reporting the number of collisions against the Poisson expectation. With -bits 64 and large -n,
+// it can detect generators like splitmix64 that never repeat outputs, and generators like wyrand
+// whose output function is not a bijection.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"hash/maphash"
+	"log"
+	"os"
+	"pgregory.net/rand/internal/sources"
+	"pgregory.net/rand/internal/stats"
+	"pgregory.net/rand/quality"
+	"strings"
+	"text/tabwriter"
+)
+
+func run(gens []string, bits uint, n int, reps int, seed uint64) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintf(w, "gen\tbits\tsamples\treps\tcollisions\texpected\tmin\tmax\tp-value\t\n")
+	for _, gen := range gens {
+		ctor, err := sources.New(gen)
+		if err != nil {
+			return err
+		}
+		g := ctor(seed)
+		total, expected := 0, 0.0
+		lo, hi := n, 0
+		for i := 0; i < reps; i++ {
+			res := quality.Collisions(g.Uint64, bits, n)
+			total += res.Collisions
+			expected += res.Expected
+			if res.Collisions < lo {
+				lo = res.Collisions
+			}
+			if res.Collisions > hi {
+				hi = res.Collisions
+			}
+		}
+		// sum of independent Poisson variables is Poisson
+		p := stats.PoissonTwoSided(total, expected)
+		_, _ = fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%.1f\t%v\t%v\t%.3g\t\n", gen, bits, n, reps, total, expected, lo, hi, p)
+	}
+	return w.Flush()
+}
+
+func main() {
+	var (
+		gens = flag.String("gen", strings.Join(sources.Names, ","), "comma-separated list of RNGs to test ("+strings.Join(sources.Names, "/")+")")
+		bits = flag.Uint("bits", 32, "number of top output bits to compare")
+		n    = flag.Int("n", 1<<20, "number of samples per test")
+		reps = flag.Int("reps", 10, "number of tests per RNG")
+		seed = flag.Uint64("seed", 0, "RNG seed (random if not specified)")
+	)
+	flag.Parse()
+
+	if *bits < 1 || *bits > 64 || *n < 1 || *reps < 1 {
+		log.Fatal("invalid -bits, -n or -reps")
+	}
+	seedSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			seedSet = true
+		}
+	})
+	if !seedSet {
+		*seed = new(maphash.Hash).Sum64()
+		fmt.Fprintf(os.Stderr, "birthday: using -seed %v\n", *seed)
+	}
+
+	err := run(strings.Split(*gens, ","), *bits, *n, *reps, *seed)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+}
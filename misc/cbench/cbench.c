@@ -0,0 +1,166 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Dependency-free C side of the cross-language benchmark suite, see misc/crossbench.
+// Prints results in the common JSON format to stdout.
+
+#define _POSIX_C_SOURCE 199309L
+
+#include <math.h>
+#include <stdint.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <time.h>
+
+#define BOUND32 UINT32_C(1000000000)
+#define BOUND64 UINT64_C(1000000000000000000)
+#define SHUFFLE_SIZE 1000
+#define EPOCHS 11
+#define MIN_EPOCH_NS 20000000.0
+
+typedef struct {
+    uint64_t a;
+    uint64_t b;
+    uint64_t c;
+    uint64_t w;
+} sfc64;
+
+static sfc64 s;
+static volatile uint64_t sink;
+
+static inline uint64_t next(void) {
+    uint64_t out = s.a + s.b + s.w;
+    s.w++;
+    s.a = s.b ^ (s.b>>11);
+    s.b = s.c + (s.c<<3);
+    s.c = ((s.c << 24) | (s.c >> (64-24))) + out;
+    return out;
+}
+
+static inline uint32_t uint32n(uint32_t n) {
+    uint64_t m = (uint64_t)(uint32_t)next() * n;
+    uint32_t l = (uint32_t)m;
+    if (l < n) {
+        uint32_t t = -n % n;
+        while (l < t) {
+            m = (uint64_t)(uint32_t)next() * n;
+            l = (uint32_t)m;
+        }
+    }
+    return m >> 32;
+}
+
+static inline uint64_t uint64n(uint64_t n) {
+    __uint128_t m = (__uint128_t)next() * n;
+    uint64_t l = (uint64_t)m;
+    if (l < n) {
+        uint64_t t = -n % n;
+        while (l < t) {
+            m = (__uint128_t)next() * n;
+            l = (uint64_t)m;
+        }
+    }
+    return m >> 64;
+}
+
+static inline double float64(void) {
+    return (double)(next() >> 11) * 0x1.0p-53;
+}
+
+// Marsaglia polar method, as used by libstdc++ std::normal_distribution.
+static inline double normfloat64(void) {
+    static int have_spare;
+    static double spare;
+    if (have_spare) {
+        have_spare = 0;
+        return spare;
+    }
+    double x, y, r;
+    do {
+        x = 2 * float64() - 1;
+        y = 2 * float64() - 1;
+        r = x*x + y*y;
+    } while (r > 1 || r == 0);
+    double m = sqrt(-2 * log(r) / r);
+    spare = y * m;
+    have_spare = 1;
+    return x * m;
+}
+
+static int perm[SHUFFLE_SIZE];
+
+static void shuffle(void) {
+    for (uint32_t i = SHUFFLE_SIZE - 1; i > 0; i--) {
+        uint32_t j = uint32n(i + 1);
+        int t = perm[i];
+        perm[i] = perm[j];
+        perm[j] = t;
+    }
+    sink += (uint64_t)perm[0];
+}
+
+static double now_ns(void) {
+    struct timespec ts;
+    clock_gettime(CLOCK_MONOTONIC, &ts);
+    return (double)ts.tv_sec * 1e9 + (double)ts.tv_nsec;
+}
+
+static int cmp_double(const void* a, const void* b) {
+    double x = *(const double*)a, y = *(const double*)b;
+    return (x > y) - (x < y);
+}
+
+#define MEASURE(expr) do { \
+    uint64_t iters = 1; \
+    double elapsed; \
+    for (;;) { \
+        double start = now_ns(); \
+        for (uint64_t i = 0; i < iters; i++) { expr; } \
+        elapsed = now_ns() - start; \
+        if (elapsed >= MIN_EPOCH_NS) break; \
+        iters *= 2; \
+    } \
+    for (int e = 0; e < EPOCHS; e++) { \
+        double start = now_ns(); \
+        for (uint64_t i = 0; i < iters; i++) { expr; } \
+        epochs[e] = (now_ns() - start) / (double)iters; \
+    } \
+} while (0)
+
+static int nresults;
+
+static void report(const char* op, double* epochs) {
+    qsort(epochs, EPOCHS, sizeof(epochs[0]), cmp_double);
+    printf("%s\n  {\"op\": \"%s\", \"ns_per_op\": %.4g}", nresults++ == 0 ? "" : ",", op, epochs[EPOCHS/2]);
+}
+
+int main(void) {
+    double epochs[EPOCHS];
+    uint64_t seed = (uint64_t)time(NULL);
+    s = (sfc64){seed, seed, seed, 1};
+    for (int i = 0; i < 12; i++) {
+        next();
+    }
+    for (int i = 0; i < SHUFFLE_SIZE; i++) {
+        perm[i] = i;
+    }
+
+    printf("{\"lang\": \"c\", \"impl\": \"sfc64\", \"results\": [");
+    MEASURE(sink += next());
+    report("Uint64", epochs);
+    MEASURE(sink += uint32n(BOUND32));
+    report("Uint32n", epochs);
+    MEASURE(sink += uint64n(BOUND64));
+    report("Uint64n", epochs);
+    MEASURE(sink += (uint64_t)(float64() * 2));
+    report("Float64", epochs);
+    MEASURE(sink += (uint64_t)(normfloat64() > 0));
+    report("NormFloat64", epochs);
+    MEASURE(shuffle());
+    report("Shuffle", epochs);
+    printf("\n]}\n");
+    return 0;
+}
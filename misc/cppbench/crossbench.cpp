@@ -0,0 +1,116 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// C++ side of the cross-language benchmark suite, see misc/crossbench.
+// Prints results in the common JSON format to stdout.
+
+#include "vendor/nanobench.h"
+#include <cstdio>
+#include <cstdint>
+#include <limits>
+#include <random>
+#include <vector>
+
+#ifndef COMPILER
+#define COMPILER "c++"
+#endif
+
+static const uint32_t bound32 = 1000000000;
+static const uint64_t bound64 = 1000000000000000000;
+static const size_t shuffleSize = 1000;
+
+struct sfc64 {
+    using result_type = uint64_t;
+
+    uint64_t a;
+    uint64_t b;
+    uint64_t c;
+    uint64_t w;
+
+    static constexpr uint64_t min() { return 0; }
+    static constexpr uint64_t max() { return std::numeric_limits<uint64_t>::max(); }
+
+    uint64_t operator()() {
+        uint64_t out = a + b + w;
+        w++;
+        a = b ^ (b>>11);
+        b = c + (c<<3);
+        c = ((c << 24) | (c >> (64-24))) + out;
+        return out;
+    }
+};
+
+uint32_t uint32n(sfc64& s, uint32_t n) {
+    uint64_t m = uint64_t(uint32_t(s())) * uint64_t(n);
+    uint32_t l = uint32_t(m);
+    if (l < n) {
+        uint32_t t = -n % n;
+        while (l < t) {
+            m = uint64_t(uint32_t(s())) * uint64_t(n);
+            l = uint32_t(m);
+        }
+    }
+    return m >> 32;
+}
+
+uint64_t uint64n(sfc64& s, uint64_t n) {
+    __uint128_t m = __uint128_t(s()) * __uint128_t(n);
+    uint64_t l = uint64_t(m);
+    if (l < n) {
+        uint64_t t = -n % n;
+        while (l < t) {
+            m = __uint128_t(s()) * __uint128_t(n);
+            l = uint64_t(m);
+        }
+    }
+    return m >> 64;
+}
+
+double float64(sfc64& s) {
+    return double(s() >> 11) * 0x1.0p-53;
+}
+
+int main() {
+    ankerl::nanobench::Rng rng;
+    sfc64 s{rng(), rng(), rng(), 1};
+    std::normal_distribution<double> norm;
+    std::vector<int> v(shuffleSize);
+
+    ankerl::nanobench::Bench b;
+    b.output(nullptr).epochs(239);
+
+    b.run("Uint64", [&]() {
+        b.doNotOptimizeAway(s());
+    });
+    b.run("Uint32n", [&]() {
+        b.doNotOptimizeAway(uint32n(s, bound32));
+    });
+    b.run("Uint64n", [&]() {
+        b.doNotOptimizeAway(uint64n(s, bound64));
+    });
+    b.run("Float64", [&]() {
+        b.doNotOptimizeAway(float64(s));
+    });
+    b.run("NormFloat64", [&]() {
+        b.doNotOptimizeAway(norm(s));
+    });
+    b.run("Shuffle", [&]() {
+        for (size_t i = v.size() - 1; i > 0; i--) {
+            size_t j = uint32n(s, uint32_t(i + 1));
+            std::swap(v[i], v[j]);
+        }
+        b.doNotOptimizeAway(v.data());
+    });
+
+    std::printf("{\"lang\": \"c++\", \"impl\": \"sfc64 (%s)\", \"results\": [", COMPILER);
+    auto const& results = b.results();
+    for (size_t i = 0; i < results.size(); i++) {
+        auto const& r = results[i];
+        std::printf("%s\n  {\"op\": \"%s\", \"ns_per_op\": %.4g}", i == 0 ? "" : ",",
+            r.config().mBenchmarkName.c_str(), r.median(ankerl::nanobench::Result::Measure::elapsed) * 1e9);
+    }
+    std::printf("\n]}\n");
+}
@@ -0,0 +1,141 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	mathrand "math/rand"
+	"pgregory.net/rand"
+	"runtime"
+	"sort"
+	"testing"
+)
+
+// Parameters of the operations, same for all languages.
+const (
+	bound32     = 1000000000
+	bound64     = 1000000000000000000
+	shuffleSize = 1000
+)
+
+var (
+	sinkUint64  uint64
+	sinkFloat64 float64
+)
+
+type op struct {
+	name string
+	fn   func(b *testing.B)
+}
+
+func randOps(seed uint64) []op {
+	r := rand.New(seed)
+	s := identity(shuffleSize)
+	return []op{
+		{"Uint64", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkUint64 = r.Uint64()
+			}
+		}},
+		{"Uint32n", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkUint64 = uint64(r.Uint32n(bound32))
+			}
+		}},
+		{"Uint64n", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkUint64 = r.Uint64n(bound64)
+			}
+		}},
+		{"Float64", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkFloat64 = r.Float64()
+			}
+		}},
+		{"NormFloat64", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkFloat64 = r.NormFloat64()
+			}
+		}},
+		{"Shuffle", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				rand.ShuffleSlice(r, s)
+			}
+		}},
+	}
+}
+
+func stdOps(seed uint64) []op {
+	r := mathrand.New(mathrand.NewSource(int64(seed)))
+	s := identity(shuffleSize)
+	return []op{
+		{"Uint64", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkUint64 = r.Uint64()
+			}
+		}},
+		{"Uint32n", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkUint64 = uint64(r.Int31n(bound32))
+			}
+		}},
+		{"Uint64n", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkUint64 = uint64(r.Int63n(bound64))
+			}
+		}},
+		{"Float64", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkFloat64 = r.Float64()
+			}
+		}},
+		{"NormFloat64", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				sinkFloat64 = r.NormFloat64()
+			}
+		}},
+		{"Shuffle", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				r.Shuffle(len(s), func(i, j int) { s[i], s[j] = s[j], s[i] })
+			}
+		}},
+	}
+}
+
+func identity(n int) []int {
+	s := make([]int, n)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}
+
+// goReports benchmarks every operation count times, reporting the median time.
+func goReports(seed uint64, count int) []Report {
+	impls := []struct {
+		impl string
+		ops  []op
+	}{
+		{"pgregory.net/rand", randOps(seed)},
+		{"math/rand", stdOps(seed)},
+	}
+
+	var reports []Report
+	for _, im := range impls {
+		report := Report{Lang: "go", Impl: im.impl + " (" + runtime.Version() + ")"}
+		for _, o := range im.ops {
+			ts := make([]float64, count)
+			for i := range ts {
+				res := testing.Benchmark(o.fn)
+				ts[i] = float64(res.T.Nanoseconds()) / float64(res.N)
+			}
+			sort.Float64s(ts)
+			report.Results = append(report.Results, Result{Op: o.name, NsPerOp: ts[count/2]})
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Command crossbench compares the speed of the same operations (Uint64, Uint32n, Uint64n,
+// Float64, NormFloat64 and Shuffle of 1000 elements) across Go, C++ and C implementations.
+//
+// Go implementations are benchmarked in-process. Native ones are separate executables
+// (see misc/cppbench and misc/cbench) that print their results to stdout in the common
+// JSON format:
+//
+//	{"lang": "c", "impl": "sfc64", "results": [{"op": "Uint64", "ns_per_op": 1.23}, ...]}
+//
+// Results of all implementations are rendered as a table, with times relative
+// to the first implementation, and optionally saved as a JSON array of reports
+// which can be passed back as an argument to compare against later runs:
+//
+//	make -C misc/cppbench crossbench-g && make -C misc/cbench
+//	go run ./misc/crossbench -native misc/cppbench/crossbench-g,misc/cbench/cbench -o results.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"hash/maphash"
+	"log"
+	"os"
+	"strings"
+)
+
+func main() {
+	var (
+		runGo  = flag.Bool("go", true, "benchmark Go implementations")
+		native = flag.String("native", "", "comma-separated list of native benchmark executables to run")
+		count  = flag.Int("count", 3, "number of times to run each Go benchmark")
+		out    = flag.String("o", "", "file to write JSON results to")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %v [flags] [results.json ...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *count < 1 {
+		log.Fatal("invalid -count")
+	}
+
+	var reports []Report
+	if *runGo {
+		reports = append(reports, goReports(new(maphash.Hash).Sum64(), *count)...)
+	}
+	if *native != "" {
+		for _, path := range strings.Split(*native, ",") {
+			rs, err := runNative(path)
+			if err != nil {
+				log.Fatal(err)
+			}
+			reports = append(reports, rs...)
+		}
+	}
+	for _, path := range flag.Args() {
+		rs, err := readReports(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		reports = append(reports, rs...)
+	}
+	if len(reports) == 0 {
+		log.Fatal("no results to compare")
+	}
+
+	if *out != "" {
+		if err := writeReports(*out, reports); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := printTable(os.Stdout, reports); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"text/tabwriter"
+)
+
+// Report is the common JSON format of benchmark results, shared by all languages.
+type Report struct {
+	Lang    string   `json:"lang"`
+	Impl    string   `json:"impl"`
+	Results []Result `json:"results"`
+}
+
+// Result is the median time of a single operation.
+type Result struct {
+	Op      string  `json:"op"`
+	NsPerOp float64 `json:"ns_per_op"`
+}
+
+func (r Report) name() string {
+	return r.Lang + " " + r.Impl
+}
+
+// parseReports accepts either a single report or an array of reports.
+func parseReports(data []byte) ([]Report, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		var reports []Report
+		err := json.Unmarshal(data, &reports)
+		return reports, err
+	}
+	var report Report
+	err := json.Unmarshal(data, &report)
+	return []Report{report}, err
+}
+
+func readReports(path string) ([]Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := parseReports(data)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return reports, nil
+}
+
+// runNative runs a benchmark executable which prints its report(s) to stdout.
+func runNative(path string) ([]Report, error) {
+	cmd := exec.Command(path)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	reports, err := parseReports(out)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return reports, nil
+}
+
+func writeReports(path string, reports []Report) error {
+	data, err := json.MarshalIndent(reports, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// printTable prints ns/op of every operation for every report,
+// together with the ratio to the first report (the baseline).
+func printTable(w io.Writer, reports []Report) error {
+	var ops []string
+	seen := map[string]bool{}
+	times := make([]map[string]float64, len(reports))
+	for i, r := range reports {
+		times[i] = map[string]float64{}
+		for _, res := range r.Results {
+			times[i][res.Op] = res.NsPerOp
+			if !seen[res.Op] {
+				seen[res.Op] = true
+				ops = append(ops, res.Op)
+			}
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	_, _ = fmt.Fprintf(tw, "op\t")
+	for _, r := range reports {
+		_, _ = fmt.Fprintf(tw, "%v\t", r.name())
+	}
+	_, _ = fmt.Fprintf(tw, "\n")
+	for _, op := range ops {
+		_, _ = fmt.Fprintf(tw, "%v\t", op)
+		base, haveBase := times[0][op]
+		for i := range reports {
+			t, ok := times[i][op]
+			switch {
+			case !ok:
+				_, _ = fmt.Fprintf(tw, "-\t")
+			case !haveBase || base == 0:
+				_, _ = fmt.Fprintf(tw, "%.4gns\t", t)
+			default:
+				_, _ = fmt.Fprintf(tw, "%.4gns (%.2fx)\t", t, t/base)
+			}
+		}
+		_, _ = fmt.Fprintf(tw, "\n")
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,37 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Command golden writes the JSON corpus of golden outputs of rand.Rand methods,
+// used to verify ports of the package to other languages. To regenerate the corpus
+// checked by the package tests, run
+//
+//	go run ./misc/golden -o testdata/golden.json
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"pgregory.net/rand/internal/golden"
+)
+
+func main() {
+	out := flag.String("o", "", "output file (stdout if not specified)")
+	flag.Parse()
+
+	data, err := golden.Marshal(golden.Generate())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(*out, data, 0o644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math"
+
+// Dirichlet fills dst with a sample from the Dirichlet distribution with concentration parameters alpha.
+// Values written to dst are non-negative and sum to 1. Dirichlet panics if len(alpha) == 0,
+// len(dst) != len(alpha), or if any of the alpha values is not positive and finite.
+func (r *Rand) Dirichlet(alpha []float64, dst []float64) {
+	if len(alpha) == 0 || len(dst) != len(alpha) {
+		panic("invalid argument to Dirichlet")
+	}
+	for _, a := range alpha {
+		if !(a > 0) || math.IsInf(a, 1) {
+			panic("invalid argument to Dirichlet")
+		}
+	}
+	sum := 0.0
+	for i, a := range alpha {
+		dst[i] = r.gamma(a)
+		sum += dst[i]
+	}
+	if sum == 0 {
+		// all shapes are tiny and all gamma variables underflowed; pick a vertex by the relative shape
+		total := 0.0
+		for _, a := range alpha {
+			total += a
+		}
+		k := len(alpha) - 1 // in case of floating-point rounding
+		u := r.Float64() * total
+		for i, a := range alpha {
+			if u < a {
+				k = i
+				break
+			}
+			u -= a
+		}
+		for i := range dst {
+			dst[i] = 0
+		}
+		dst[k] = 1
+		return
+	}
+	for i := range dst {
+		dst[i] /= sum
+	}
+}
+
+// Multinomial fills dst with the counts of outcomes of n independent trials, where the probability
+// of outcome i is proportional to p[i]. Values written to dst are non-negative and sum to n.
+// Multinomial panics if n < 0, len(dst) != len(p), any of the p values is negative or not finite,
+// or all of them are zero.
+//
+// Multinomial is exact for any n, and runs in O(len(p) * log(n)) time.
+func (r *Rand) Multinomial(n int, p []float64, dst []int) {
+	if n < 0 || len(dst) != len(p) {
+		panic("invalid argument to Multinomial")
+	}
+	total, largest := 0.0, 0.0
+	last := -1
+	for i, q := range p {
+		if !(q >= 0) || math.IsInf(q, 1) {
+			panic("invalid argument to Multinomial")
+		}
+		if q > 0 {
+			last = i
+		}
+		total += q
+		if q > largest {
+			largest = q
+		}
+	}
+	if last < 0 {
+		panic("invalid argument to Multinomial")
+	}
+	scale := 1.0
+	if math.IsInf(total, 1) {
+		// finite weights with a sum that overflows
+		scale = largest
+		total = 0
+		for _, q := range p {
+			total += q / scale
+		}
+	}
+	// conditional binomial method: outcome i gets Binomial(remaining trials, p[i] / remaining mass)
+	for i, q := range p {
+		switch {
+		case i == last:
+			dst[i] = n
+			n = 0
+		case q == 0 || n == 0:
+			dst[i] = 0
+		default:
+			q /= scale
+			prob := q / total
+			if prob > 1 {
+				prob = 1 // floating-point rounding
+			}
+			dst[i] = r.binomial(n, prob)
+			n -= dst[i]
+			total -= q
+		}
+	}
+}
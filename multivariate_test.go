@@ -0,0 +1,137 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+)
+
+func TestRand_Dirichlet(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		alpha := rapid.SliceOfN(rapid.SampledFrom([]float64{1e-300, 1e-10, 1e-3, 0.1, 0.5, 1, 2, 10, 1e3}), 1, 10).Draw(t, "alpha").([]float64)
+		dst := make([]float64, len(alpha))
+		r.Dirichlet(alpha, dst)
+		sum := 0.0
+		for _, x := range dst {
+			if x < 0 || x > 1 {
+				t.Fatalf("got %v outside of [0, 1]", x)
+			}
+			sum += x
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Fatalf("got sum %v instead of 1", sum)
+		}
+	})
+}
+
+func TestRand_DirichletTiny(t *testing.T) {
+	// for tiny alpha, almost all of the mass is at a single vertex, chosen in proportion to alpha
+	const iters = 40000
+	alpha := []float64{1e-10, 1e-10, 2e-10}
+	r := rand.New(1)
+	dst := make([]float64, len(alpha))
+	counts := make([]int, len(alpha))
+	for i := 0; i < iters; i++ {
+		r.Dirichlet(alpha, dst)
+		k := 0
+		for j, x := range dst {
+			if x > dst[k] {
+				k = j
+			}
+		}
+		counts[k]++
+	}
+	for i, c := range counts {
+		want := iters * alpha[i] / 4e-10
+		if math.Abs(float64(c)-want) > 5*math.Sqrt(want) {
+			t.Errorf("vertex %v chosen %v times instead of %v", i, c, want)
+		}
+	}
+}
+
+func TestRand_Multinomial(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		n := rapid.IntRange(0, math.MaxInt32).Draw(t, "n").(int)
+		p := rapid.SliceOfN(rapid.SampledFrom([]float64{0, 1e-9, 0.1, 1, 3}), 1, 10).Draw(t, "p").([]float64)
+		p[len(p)-1] = 1
+		dst := make([]int, len(p))
+		r.Multinomial(n, p, dst)
+		sum := 0
+		for i, k := range dst {
+			if k < 0 {
+				t.Fatalf("got negative count %v", k)
+			}
+			if p[i] == 0 && k != 0 {
+				t.Fatalf("got count %v for zero probability outcome", k)
+			}
+			sum += k
+		}
+		if sum != n {
+			t.Fatalf("got total count %v instead of %v", sum, n)
+		}
+	})
+}
+
+func TestRand_MultinomialOverflow(t *testing.T) {
+	const n = 10000
+	r := rand.New(1)
+	p := []float64{1e308, 1e308, 1}
+	dst := make([]int, len(p))
+	r.Multinomial(n, p, dst)
+	if dst[2] != 0 || math.Abs(float64(dst[0])-n/2) > 4*math.Sqrt(n) {
+		t.Errorf("got counts %v for p %v", dst, p)
+	}
+}
+
+func TestRand_DirichletMoments(t *testing.T) {
+	alphas := [][]float64{{1, 1, 1}, {0.1, 0.2, 0.7}, {5, 2}, {100, 200, 300, 400}}
+	for _, alpha := range alphas {
+		a0 := 0.0
+		for _, a := range alpha {
+			a0 += a
+		}
+		r := rand.New(uint64(testSeeds[0]))
+		samples := make([][]float64, len(alpha))
+		dst := make([]float64, len(alpha))
+		for i := 0; i < numTestSamples; i++ {
+			r.Dirichlet(alpha, dst)
+			for j, x := range dst {
+				samples[j] = append(samples[j], x)
+			}
+		}
+		for j, a := range alpha {
+			mean := a / a0
+			stddev := math.Sqrt(a * (a0 - a) / (a0 * a0 * (a0 + 1)))
+			checkSampleDistribution(t, samples[j], &statsResults{mean, stddev, 0.001, 0.05})
+		}
+	}
+}
+
+func TestRand_MultinomialMoments(t *testing.T) {
+	p := []float64{0.5, 0.001, 0.25, 0.249}
+	for _, n := range []int{10, 1000, 1e9} {
+		r := rand.New(uint64(testSeeds[0]))
+		samples := make([][]float64, len(p))
+		dst := make([]int, len(p))
+		for i := 0; i < numTestSamples; i++ {
+			r.Multinomial(n, p, dst)
+			for j, k := range dst {
+				samples[j] = append(samples[j], float64(k))
+			}
+		}
+		for j, q := range p {
+			mean := float64(n) * q
+			stddev := math.Sqrt(float64(n) * q * (1 - q))
+			checkSampleDistribution(t, samples[j], &statsResults{mean, stddev, 0.1, 0.05})
+		}
+	}
+}
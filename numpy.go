@@ -0,0 +1,82 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+// NewNumPy returns a generator seeded the same way as numpy.random.SFC64(seed):
+// seed is expanded into the initial state with NumPy's SeedSequence algorithm.
+// The stream of [Rand.Uint64] outputs of the generator is identical
+// to the stream of random_raw() outputs of the NumPy one.
+//
+// Results of other methods are in general different from the results
+// of similarly named methods of numpy.random.Generator.
+func NewNumPy(seed uint64) *Rand {
+	var s [3]uint64
+	numpySeedSequence(seed, s[:])
+	var r Rand
+	r.init(s[0], s[1], s[2])
+	return &r
+}
+
+const (
+	numpyPoolSize = 4
+	numpyInitA    = 0x43b0d7e5
+	numpyMultA    = 0x931e8875
+	numpyInitB    = 0x8b51f9dd
+	numpyMultB    = 0x58f38ded
+	numpyMixMulL  = 0xca01f9dd
+	numpyMixMulR  = 0x4973f715
+	numpyXShift   = 16
+)
+
+// numpySeedSequence fills dst the same way as numpy.random.SeedSequence(seed).generate_state(len(dst), numpy.uint64).
+func numpySeedSequence(seed uint64, dst []uint64) {
+	entropy := []uint32{uint32(seed)}
+	if seed>>32 != 0 {
+		entropy = append(entropy, uint32(seed>>32))
+	}
+
+	// mix entropy into the pool
+	var pool [numpyPoolSize]uint32
+	h := uint32(numpyInitA)
+	hashmix := func(v uint32) uint32 {
+		v ^= h
+		h *= numpyMultA
+		v *= h
+		return v ^ v>>numpyXShift
+	}
+	mix := func(x uint32, y uint32) uint32 {
+		v := numpyMixMulL*x - numpyMixMulR*y
+		return v ^ v>>numpyXShift
+	}
+	for i := range pool {
+		if i < len(entropy) {
+			pool[i] = hashmix(entropy[i])
+		} else {
+			pool[i] = hashmix(0)
+		}
+	}
+	for i := range pool {
+		for j := range pool {
+			if i != j {
+				pool[j] = mix(pool[j], hashmix(pool[i]))
+			}
+		}
+	}
+
+	// generate the state, 32 bits at a time, low word first
+	h = numpyInitB
+	for i := range dst {
+		var w [2]uint64
+		for k := range w {
+			v := pool[(2*i+k)%numpyPoolSize] ^ h
+			h *= numpyMultB
+			v *= h
+			w[k] = uint64(v ^ v>>numpyXShift)
+		}
+		dst[i] = w[0] | w[1]<<32
+	}
+}
@@ -0,0 +1,225 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package quality
+
+import (
+	"math"
+	"math/bits"
+	"pgregory.net/rand/internal/stats"
+)
+
+// Outputs are treated as a stream of bits, most significant bit of each output first.
+
+// Monobit is the frequency test from NIST SP 800-22: it checks that the numbers
+// of zero and one bits in n outputs are close. It panics if n < 1.
+func Monobit(next func() uint64, n int) Result {
+	if n < 1 {
+		panic("invalid argument to Monobit")
+	}
+	ones := 0
+	for i := 0; i < n; i++ {
+		ones += bits.OnesCount64(next())
+	}
+	total := 64 * float64(n)
+	s := (2*float64(ones) - total) / math.Sqrt(total)
+	return Result{"Monobit", stats.NormalTwoSided(s)}
+}
+
+// Runs is the runs test from NIST SP 800-22: it checks that the number of runs of identical bits
+// in n outputs is consistent with the proportion of one bits. It panics if n < 1.
+func Runs(next func() uint64, n int) Result {
+	if n < 1 {
+		panic("invalid argument to Runs")
+	}
+	ones, runs := 0, 1
+	var prev uint64
+	for i := 0; i < n; i++ {
+		u := next()
+		ones += bits.OnesCount64(u)
+		runs += bits.OnesCount64((u ^ u>>1) &^ (1 << 63))
+		if i > 0 && prev&1 != u>>63 {
+			runs++
+		}
+		prev = u
+	}
+	total := 64 * float64(n)
+	pi := float64(ones) / total
+	if math.Abs(pi-0.5) >= 2/math.Sqrt(total) {
+		return Result{"Runs", 0} // frequency test prerequisite failed
+	}
+	q := pi * (1 - pi)
+	return Result{"Runs", math.Erfc(math.Abs(float64(runs)-2*total*q) / (2 * math.Sqrt(2*total) * q))}
+}
+
+var rankProbabilities = func() [3]float64 {
+	// probability that a random 64×64 binary matrix has rank r, from "On the rank of random
+	// matrices" by Ian F. Blake and Chris Studholme
+	prob := func(r int) float64 {
+		p := math.Exp2(float64(r*(128-r) - 64*64))
+		for i := 0; i < r; i++ {
+			f := 1 - math.Exp2(float64(i-64))
+			p *= f * f / (1 - math.Exp2(float64(i-r)))
+		}
+		return p
+	}
+	p64, p63 := prob(64), prob(63)
+	return [3]float64{p64, p63, 1 - p64 - p63}
+}()
+
+// MatrixRank is the binary matrix rank test from NIST SP 800-22: it checks the distribution
+// of ranks over GF(2) of 64×64 binary matrices made from n outputs, one output per row.
+// It panics if n < 64.
+func MatrixRank(next func() uint64, n int) Result {
+	if n < 64 {
+		panic("invalid argument to MatrixRank")
+	}
+	var counts [3]int
+	var m [64]uint64
+	for k := 0; k < n/64; k++ {
+		for i := range m {
+			m[i] = next()
+		}
+		switch rank(&m) {
+		case 64:
+			counts[0]++
+		case 63:
+			counts[1]++
+		default:
+			counts[2]++
+		}
+	}
+	return Result{"MatrixRank", chiSquare(counts[:], rankProbabilities[:])}
+}
+
+func rank(m *[64]uint64) int {
+	r := 0
+	for bit := 63; bit >= 0 && r < 64; bit-- {
+		mask := uint64(1) << bit
+		pivot := -1
+		for i := r; i < 64; i++ {
+			if m[i]&mask != 0 {
+				pivot = i
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		m[r], m[pivot] = m[pivot], m[r]
+		for i := r + 1; i < 64; i++ {
+			if m[i]&mask != 0 {
+				m[i] ^= m[r]
+			}
+		}
+		r++
+	}
+	return r
+}
+
+const linearComplexityWords = 8 // 512-bit blocks
+
+var linearComplexityProbabilities = []float64{0.010417, 0.03125, 0.125, 0.5, 0.25, 0.0625, 0.020833}
+
+// bitset is a 512-bit block, bit i in word i/64.
+type bitset [linearComplexityWords]uint64
+
+// LinearComplexity is the linear complexity test from NIST SP 800-22: it checks the distribution
+// of linear complexities of 512-bit blocks made from n outputs, computed using the Berlekamp–Massey
+// algorithm. It panics if n < 8.
+func LinearComplexity(next func() uint64, n int) Result {
+	const m = 64 * linearComplexityWords
+	if n < linearComplexityWords {
+		panic("invalid argument to LinearComplexity")
+	}
+	mu := m/2.0 + 8/36.0 - (m/3.0+2/9.0)/math.Exp2(m)
+	counts := make([]int, 7)
+	var s bitset
+	for k := 0; k < n/linearComplexityWords; k++ {
+		for i := range s {
+			s[i] = bits.Reverse64(next()) // bit i of s is i-th bit of the stream
+		}
+		t := float64(berlekampMassey(&s)) - mu + 2/9.0
+		switch {
+		case t <= -2.5:
+			counts[0]++
+		case t <= -1.5:
+			counts[1]++
+		case t <= -0.5:
+			counts[2]++
+		case t <= 0.5:
+			counts[3]++
+		case t <= 1.5:
+			counts[4]++
+		case t <= 2.5:
+			counts[5]++
+		default:
+			counts[6]++
+		}
+	}
+	return Result{"LinearComplexity", chiSquare(counts, linearComplexityProbabilities)}
+}
+
+// berlekampMassey returns the length of the shortest linear feedback shift register generating s.
+func berlekampMassey(s *bitset) int {
+	// connection polynomials c and b have coefficient of x^j in bit j; window has s[i-j] in bit j,
+	// so that the discrepancy is the parity of c & window
+	var c, b, t, window bitset
+	c[0], b[0] = 1, 1
+	l, m := 0, -1
+	for i := 0; i < 64*linearComplexityWords; i++ {
+		window.shiftLeft(1)
+		window[0] |= s[i/64] >> (i % 64) & 1
+		d := 0
+		for j := range c {
+			d += bits.OnesCount64(c[j] & window[j])
+		}
+		if d%2 == 0 {
+			continue
+		}
+		t = c
+		shifted := b
+		shifted.shiftLeft(i - m)
+		for j := range c {
+			c[j] ^= shifted[j]
+		}
+		if l <= i/2 {
+			l = i + 1 - l
+			m = i
+			b = t
+		}
+	}
+	return l
+}
+
+func (s *bitset) shiftLeft(k int) {
+	words, rem := k/64, uint(k%64)
+	for i := len(s) - 1; i >= 0; i-- {
+		var w uint64
+		if i-words >= 0 {
+			w = s[i-words] << rem
+			if rem != 0 && i-words-1 >= 0 {
+				w |= s[i-words-1] >> (64 - rem)
+			}
+		}
+		s[i] = w
+	}
+}
+
+// chiSquare returns the p-value of Pearson's chi-squared test of counts against probabilities.
+func chiSquare(counts []int, probabilities []float64) float64 {
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	var χ2 float64
+	for i, c := range counts {
+		want := float64(total) * probabilities[i]
+		d := float64(c) - want
+		χ2 += d * d / want
+	}
+	return stats.ChiSquareSF(χ2, float64(len(counts)-1))
+}
@@ -0,0 +1,55 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package quality
+
+import (
+	"math"
+	"pgregory.net/rand/internal/stats"
+	"sort"
+)
+
+// CollisionsResult is the outcome of the [Collisions] test.
+type CollisionsResult struct {
+	Result
+	Collisions int     // number of samples equal to some earlier sample
+	Expected   float64 // expected number of collisions for uniformly distributed samples
+}
+
+// Collisions is the birthday test as described in https://www.pcg-random.org/posts/birthday-test.html:
+// it counts repeated values among n samples of the top bits of outputs. For independent uniformly
+// distributed samples, the number of collisions approximately follows Poisson distribution with mean
+// n - 2^bits·(1 - (1 - 2^-bits)^n) ≈ n²/2^(bits+1). Generators that produce every output exactly
+// once per period (like splitmix64) produce too few collisions when bits is 64, while generators with
+// non-uniform output function produce too many. The test needs 8·n bytes of memory.
+// It panics if n < 1 or bits is not in [1, 64].
+func Collisions(next func() uint64, bits uint, n int) CollisionsResult {
+	if n < 1 || bits < 1 || bits > 64 {
+		panic("invalid argument to Collisions")
+	}
+	s := make([]uint64, n)
+	for i := range s {
+		s[i] = next() >> (64 - bits)
+	}
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+	c := 0
+	for i := 1; i < len(s); i++ {
+		if s[i] == s[i-1] {
+			c++
+		}
+	}
+	// expected number of distinct values is m·(1 - (1 - 1/m)^n) for m = 2^bits
+	m := math.Exp2(float64(bits))
+	lambda := float64(n) + m*math.Expm1(float64(n)*math.Log1p(-1/m))
+	if float64(n)/m < 1e-6 {
+		lambda = float64(n) * float64(n-1) / (2 * m) // exact formula suffers from cancellation, approximation is precise
+	}
+	return CollisionsResult{
+		Result:     Result{"Collisions", stats.PoissonTwoSided(c, lambda)},
+		Collisions: c,
+		Expected:   lambda,
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package quality implements statistical tests of pseudo-random number generator output quality.
+//
+// Tests accept any func() uint64 as a source of pseudo-random data, consume n outputs of it
+// and report a p-value: a probability of observing a test statistic at least as extreme
+// under the hypothesis that the outputs are independent and uniformly distributed.
+// A good generator produces p-values that are themselves uniformly distributed in [0, 1];
+// p-values consistently close to 0 indicate a defect.
+//
+// Tests are pure Go and need no external tools; they are much less thorough than dedicated
+// batteries like TestU01 or PractRand, and are intended for catching gross defects in CI.
+package quality
+
+import (
+	"math/bits"
+)
+
+// Result is the outcome of a single statistical test.
+type Result struct {
+	Name   string
+	PValue float64
+}
+
+// Failed reports whether the p-value of the test is below alpha.
+func (r Result) Failed(alpha float64) bool {
+	return r.PValue < alpha
+}
+
+var tests = []struct {
+	name  string
+	fn    func(func() uint64, int) Result
+	scale int // n is divided by scale for tests that are much slower per output
+}{
+	{"Monobit", Monobit, 1},
+	{"Runs", Runs, 1},
+	{"Gap", Gap, 1},
+	{"BirthdaySpacings", BirthdaySpacings, 1},
+	{"Poker", Poker, 1},
+	{"SerialCorrelation", SerialCorrelation, 1},
+	{"MatrixRank", MatrixRank, 1},
+	{"LinearComplexity", LinearComplexity, 64},
+}
+
+var transforms = []struct {
+	name string
+	fn   func(func() uint64) func() uint64
+}{
+	{"", func(next func() uint64) func() uint64 { return next }},
+	{"reversed", reversed},
+	{"low8", low8},
+}
+
+// Battery runs every test of the package with n outputs of next (fewer for slow tests),
+// on raw outputs as well as on transformed ones: with reversed bit order, so that tests
+// sensitive to high bits examine the low bits, and with 8 lowest bits of 8 consecutive outputs
+// combined into one. It is a very small-scale analogue of the BigCrush battery from TestU01.
+// Battery panics if n is too small for any of the tests.
+func Battery(next func() uint64, n int) []Result {
+	var results []Result
+	for _, tr := range transforms {
+		src := tr.fn(next)
+		for _, test := range tests {
+			res := test.fn(src, n/test.scale)
+			if tr.name != "" {
+				res.Name += "/" + tr.name
+			}
+			results = append(results, res)
+		}
+	}
+	return results
+}
+
+func reversed(next func() uint64) func() uint64 {
+	return func() uint64 {
+		return bits.Reverse64(next())
+	}
+}
+
+func low8(next func() uint64) func() uint64 {
+	return func() uint64 {
+		var u uint64
+		for i := 0; i < 8; i++ {
+			u = u<<8 | next()&0xff
+		}
+		return u
+	}
+}
+
+// unitFloat64 maps the top 53 bits of u to a float64 in [0, 1), like rand.Rand.Float64 does.
+func unitFloat64(u uint64) float64 {
+	return float64(u>>11) * 0x1.0p-53
+}
@@ -0,0 +1,200 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package quality_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rand/quality"
+	"testing"
+)
+
+const (
+	numOutputs = 1 << 16
+	alpha      = 1e-4
+)
+
+var allTests = []struct {
+	name string
+	fn   func(func() uint64, int) quality.Result
+}{
+	{"Monobit", quality.Monobit},
+	{"Runs", quality.Runs},
+	{"Gap", quality.Gap},
+	{"BirthdaySpacings", quality.BirthdaySpacings},
+	{"Poker", quality.Poker},
+	{"SerialCorrelation", quality.SerialCorrelation},
+	{"MatrixRank", quality.MatrixRank},
+	{"LinearComplexity", quality.LinearComplexity},
+}
+
+func counter() func() uint64 {
+	var c uint64
+	return func() uint64 {
+		c += 0x9e3779b97f4a7c15
+		return c
+	}
+}
+
+func lcg() func() uint64 {
+	var s uint64 = 1
+	return func() uint64 {
+		s = s*6364136223846793005 + 1442695040888963407
+		return s
+	}
+}
+
+func biased() func() uint64 {
+	r := rand.New(1)
+	return func() uint64 {
+		return r.Uint64() | r.Uint64()&(1<<40) // one bit is set with probability 3/4
+	}
+}
+
+func xorshift() func() uint64 {
+	// xorshift64 is linear over GF(2), and fails the matrix rank test
+	var s uint64 = 1
+	return func() uint64 {
+		s ^= s << 13
+		s ^= s >> 7
+		s ^= s << 17
+		return s
+	}
+}
+
+func lfsr() func() uint64 {
+	// bit stream of a 32-bit Galois LFSR has linear complexity 32
+	var s uint32 = 1
+	return func() uint64 {
+		var u uint64
+		for i := 0; i < 64; i++ {
+			bit := s & 1
+			s >>= 1
+			if bit != 0 {
+				s ^= 0x80200003
+			}
+			u = u<<1 | uint64(bit)
+		}
+		return u
+	}
+}
+
+func nibbles15() func() uint64 {
+	// hexadecimal digits never take one of 16 values
+	r := rand.New(1)
+	return func() uint64 {
+		var u uint64
+		for i := 0; i < 16; i++ {
+			u = u<<4 | r.Uint64n(15)
+		}
+		return u
+	}
+}
+
+func TestGoodGenerator(t *testing.T) {
+	for _, test := range allTests {
+		t.Run(test.name, func(t *testing.T) {
+			for seed := uint64(0); seed < 10; seed++ {
+				r := rand.New(seed)
+				if res := test.fn(r.Uint64, numOutputs); res.Name != test.name || res.Failed(alpha) {
+					t.Errorf("seed %v: %v", seed, res)
+				}
+			}
+		})
+	}
+}
+
+func TestBadGenerators(t *testing.T) {
+	tests := []struct {
+		name string
+		gen  func() func() uint64
+		test func(func() uint64, int) quality.Result
+	}{
+		{"biased/Monobit", biased, quality.Monobit},
+		{"biased/Runs", biased, quality.Runs},
+		{"counter/Gap", counter, quality.Gap},
+		{"counter/BirthdaySpacings", counter, quality.BirthdaySpacings},
+		{"counter/SerialCorrelation", counter, quality.SerialCorrelation},
+		{"nibbles15/Poker", nibbles15, quality.Poker},
+		{"xorshift/MatrixRank", xorshift, quality.MatrixRank},
+		{"lfsr/LinearComplexity", lfsr, quality.LinearComplexity},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if res := test.test(test.gen(), numOutputs); !res.Failed(alpha) {
+				t.Errorf("defect not detected: %v", res)
+			}
+		})
+	}
+}
+
+func TestBattery(t *testing.T) {
+	r := rand.New(1)
+	results := quality.Battery(r.Uint64, numOutputs)
+	if len(results) != 3*len(allTests) {
+		t.Fatalf("got %v results instead of %v", len(results), 3*len(allTests))
+	}
+	for _, res := range results {
+		if res.Failed(alpha) {
+			t.Errorf("%v", res)
+		}
+	}
+
+	failed := 0
+	for _, res := range quality.Battery(lcg(), numOutputs) {
+		if res.Failed(alpha) {
+			failed++
+		}
+	}
+	if failed == 0 {
+		t.Errorf("no defects detected in LCG")
+	}
+}
+
+func BenchmarkBattery(b *testing.B) {
+	r := rand.New(1)
+	for i := 0; i < b.N; i++ {
+		quality.Battery(r.Uint64, numOutputs)
+	}
+}
+
+func TestCollisions(t *testing.T) {
+	r := rand.New(1)
+	for _, bits := range []uint{16, 24, 32, 64} {
+		for _, next := range []func() uint64{r.Uint64, rand.Uint64} {
+			if res := quality.Collisions(next, bits, numOutputs); res.Failed(alpha) {
+				t.Errorf("bits %v: %+v", bits, res)
+			}
+		}
+	}
+
+	// 16 top bits of a bijection: every sample is unique, while collisions are expected
+	var i uint64
+	bijection := func() uint64 {
+		i++
+		return (i * 0x9e3779b97f4a7c15) << 48
+	}
+	if res := quality.Collisions(bijection, 16, 1<<16); res.Collisions != 0 || !res.Failed(alpha) {
+		t.Errorf("bijection: %+v", res)
+	}
+}
+
+func TestCollisions_Expected(t *testing.T) {
+	// compare expected number of collisions with the observed mean
+	const bits, n, reps = 10, 2000, 1000
+	r := rand.New(1)
+	total, expected := 0, 0.0
+	for i := 0; i < reps; i++ {
+		res := quality.Collisions(r.Uint64, bits, n)
+		total += res.Collisions
+		expected = res.Expected
+	}
+	mean := float64(total) / reps
+	if math.Abs(mean-expected) > 0.01*expected {
+		t.Errorf("mean number of collisions %v, expected %v", mean, expected)
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package quality
+
+import (
+	"math"
+	"math/bits"
+	"pgregory.net/rand/internal/stats"
+	"sort"
+)
+
+const (
+	gapLimit = 16
+
+	birthdays    = 4096
+	birthdayBits = 34 // expected number of duplicate spacings per test is birthdays³/(4·2^birthdayBits) = 1
+
+	pokerHand = 5
+)
+
+// Gap is the gap test from Knuth's TAOCP volume 2: it checks the distribution of lengths of gaps
+// between outputs that fall into the lowest quarter of the range, among n outputs. It panics if n < 1.
+func Gap(next func() uint64, n int) Result {
+	if n < 1 {
+		panic("invalid argument to Gap")
+	}
+	const p = 0.25
+	counts := make([]int, gapLimit+1)
+	gap := 0
+	for i := 0; i < n; i++ {
+		if next()>>62 == 0 {
+			counts[min(gap, gapLimit)]++
+			gap = 0
+		} else {
+			gap++
+		}
+	}
+	probabilities := make([]float64, gapLimit+1)
+	for r := range probabilities {
+		probabilities[r] = p * math.Pow(1-p, float64(r))
+	}
+	probabilities[gapLimit] = math.Pow(1-p, gapLimit)
+	return Result{"Gap", chiSquare(counts, probabilities)}
+}
+
+// BirthdaySpacings is the birthday spacings test from Marsaglia's Diehard battery: top 34 bits
+// of outputs are treated as birthdays in a year of 2^34 days, and the number of duplicate
+// spacings between sorted birthdays in groups of 4096 outputs, which follows Poisson distribution,
+// is checked. It panics if n < 4096.
+func BirthdaySpacings(next func() uint64, n int) Result {
+	if n < birthdays {
+		panic("invalid argument to BirthdaySpacings")
+	}
+	b := make([]uint64, birthdays)
+	dups := 0
+	groups := n / birthdays
+	for k := 0; k < groups; k++ {
+		for i := range b {
+			b[i] = next() >> (64 - birthdayBits)
+		}
+		dups += duplicateSpacings(b)
+	}
+	lambda := float64(groups) * birthdays * birthdays * birthdays / (4 * math.Exp2(birthdayBits))
+	return Result{"BirthdaySpacings", stats.PoissonTwoSided(dups, lambda)}
+}
+
+// duplicateSpacings sorts b and returns the number of repeated values among spacings between elements of b.
+func duplicateSpacings(b []uint64) int {
+	sort.Slice(b, func(i, j int) bool { return b[i] < b[j] })
+	for i := len(b) - 1; i > 0; i-- {
+		b[i] -= b[i-1]
+	}
+	sort.Slice(b, func(i, j int) bool { return b[i] < b[j] })
+	dups := 0
+	for i := 1; i < len(b); i++ {
+		if b[i] == b[i-1] {
+			dups++
+		}
+	}
+	return dups
+}
+
+// Poker is the poker test from Knuth's TAOCP volume 2: outputs are split into hands of 5 hexadecimal
+// digits, and the distribution of the number of distinct digits in a hand is checked. It panics if n < 1.
+func Poker(next func() uint64, n int) Result {
+	if n < 1 {
+		panic("invalid argument to Poker")
+	}
+	counts := make([]int, pokerHand)
+	for i := 0; i < n; i++ {
+		u := next()
+		for h := 0; h < 64/(4*pokerHand); h++ {
+			var seen uint16
+			for j := 0; j < pokerHand; j++ {
+				seen |= 1 << (u & 0xf)
+				u >>= 4
+			}
+			counts[bits.OnesCount16(seen)-1]++
+		}
+	}
+	// probability of r distinct digits is S(5, r)·16·15·…·(16-r+1)/16^5, with S Stirling numbers of the second kind
+	stirling := []float64{1, 15, 25, 10, 1}
+	probabilities := make([]float64, pokerHand)
+	falling := 1.0
+	for r := 1; r <= pokerHand; r++ {
+		falling *= float64(16 - r + 1)
+		probabilities[r-1] = stirling[r-1] * falling / math.Pow(16, pokerHand)
+	}
+	// hands with 1 distinct digit are too rare for chi-squared test
+	counts[1] += counts[0]
+	probabilities[1] += probabilities[0]
+	return Result{"Poker", chiSquare(counts[1:], probabilities[1:])}
+}
+
+// SerialCorrelation is the serial correlation test from Knuth's TAOCP volume 2: it checks that
+// the correlation coefficient between consecutive outputs, interpreted as numbers in [0, 1),
+// is close to zero. It panics if n < 3.
+func SerialCorrelation(next func() uint64, n int) Result {
+	if n < 3 {
+		panic("invalid argument to SerialCorrelation")
+	}
+	first := unitFloat64(next())
+	prev := first
+	sum, sumSq, sumProd := first, first*first, 0.0
+	for i := 1; i < n; i++ {
+		u := unitFloat64(next())
+		sum += u
+		sumSq += u * u
+		sumProd += prev * u
+		prev = u
+	}
+	sumProd += prev * first
+	nf := float64(n)
+	c := (nf*sumProd - sum*sum) / (nf*sumSq - sum*sum)
+	mu := -1 / (nf - 1)
+	sigma := nf / (nf - 1) / math.Sqrt(nf-2)
+	return Result{"SerialCorrelation", stats.NormalTwoSided((c - mu) / sigma)}
+}
+
+func min(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -49,6 +49,7 @@ const (
 //
 // [SFC64]: http://pracrand.sourceforge.net/RNG_engines.txt
 type Rand struct {
+	fuzzState // zero-sized unless built with randfuzz tag, see FromBytes
 	sfc64
 	val uint64
 	pos int
@@ -82,6 +83,7 @@ func (r *Rand) Seed(seed uint64) {
 	r.init1(seed)
 	r.val = 0
 	r.pos = 0
+	r.fuzzState = fuzzState{}
 }
 
 // MarshalBinary returns the binary representation of the current state of the generator.
@@ -177,6 +179,14 @@ func (r *Rand) Perm(n int) []int {
 	return p
 }
 
+// PermInto fills dst with a pseudo-random permutation of the integers in the half-open interval [0, len(dst)).
+func (r *Rand) PermInto(dst []int) {
+	if len(dst) > 0 {
+		dst[0] = 0
+	}
+	r.perm(dst)
+}
+
 func (r *Rand) perm(p []int) {
 	n := len(p)
 	b := n
@@ -284,3 +294,38 @@ func (r *Rand) Uint64n(n uint64) uint64 {
 	_, carry := bits.Add64(frac, hi, 0)
 	return res + carry
 }
+
+// Uint128n returns, as a pair of high and low 64-bit halves, a uniformly distributed pseudo-random number
+// in [0, n), where n is the 128-bit number with high and low halves hi and lo. Uint128n(0, 0) returns (0, 0).
+func (r *Rand) Uint128n(hi uint64, lo uint64) (uint64, uint64) {
+	if hi == 0 {
+		return 0, r.Uint64n(lo)
+	}
+	xh := r.next64()
+	xl := r.next64()
+	return mul128n(hi, lo, xh, xl, r.next64())
+}
+
+// mul128n is the 128-bit version of Uint64n: it returns the integer part of n*x, where n is (nh, nl)
+// and x is the 128-bit fraction (xh, xl), corrected using the additional 64-bit fraction w.
+func mul128n(nh uint64, nl uint64, xh uint64, xl uint64, w uint64) (uint64, uint64) {
+	a1, a0 := bits.Mul64(nl, xl)
+	b1, b0 := bits.Mul64(nl, xh)
+	c1, c0 := bits.Mul64(nh, xl)
+	d1, d0 := bits.Mul64(nh, xh)
+	p1, k1 := bits.Add64(a1, b0, 0)
+	p1, k2 := bits.Add64(p1, c0, 0)
+	p2, k3 := bits.Add64(b1, c1, k1)
+	p2, k4 := bits.Add64(p2, d0, k2)
+	p3 := d1 + k3 + k4
+
+	e1, e0 := bits.Mul64(nh, w)
+	f1, _ := bits.Mul64(nl, w)
+	q1, k5 := bits.Add64(e0, f1, 0)
+	q2 := e1 + k5
+
+	_, k6 := bits.Add64(a0, q1, 0)
+	_, k7 := bits.Add64(p1, q2, k6)
+	lo, k8 := bits.Add64(p2, k7, 0)
+	return p3 + k8, lo
+}
@@ -17,3 +17,17 @@ func GetExponentialDistributionParameters() (float64, [256]uint64, [256]float64,
 }
 
 var ShuffleSliceGeneric func(*Rand, []int)
+
+var ShuffleSlicePrefixGeneric func(*Rand, []int, int)
+
+func GetZigguratParameters(z *Ziggurat) (float64, [256]uint64, [256]float64, [256]float64) {
+	return z.edge, z.k, z.w, z.f
+}
+
+func GetNormalDistributionParameters32() ([256]uint32, [256]float32, [256]float32) {
+	return kn32, wn32, fn32
+}
+
+func GetExponentialDistributionParameters32() ([256]uint32, [256]float32, [256]float32) {
+	return ke32, we32, fe32
+}
@@ -37,3 +37,183 @@ func ShuffleSlice[S ~[]E, E any](r *Rand, s S) {
 		}
 	}
 }
+
+// ShuffleSlicePrefix pseudo-randomizes the first k elements of s, so that they become
+// a uniformly distributed pseudo-random selection of k elements of s, in random order.
+// The remaining elements of s are left in unspecified order. ShuffleSlicePrefix runs in O(k) time
+// and panics if k < 0 or k > len(s).
+//
+// When r is nil, ShuffleSlicePrefix uses non-deterministic goroutine-local
+// pseudo-random data source, and is safe for concurrent use from multiple goroutines.
+func ShuffleSlicePrefix[S ~[]E, E any](r *Rand, s S, k int) {
+	n := len(s)
+	if k < 0 || k > n {
+		panic("invalid argument to ShuffleSlicePrefix")
+	}
+	if k == n {
+		k-- // last element has nowhere to go
+	}
+	if r == nil {
+		i := 0
+		for ; i < k && n-i > math.MaxInt32; i++ {
+			j := i + int(Uint64n(uint64(n-i)))
+			s[i], s[j] = s[j], s[i]
+		}
+		for ; i < k; i++ {
+			j := i + int(Uint32n(uint32(n-i)))
+			s[i], s[j] = s[j], s[i]
+		}
+	} else {
+		i := 0
+		for ; i < k && n-i > math.MaxInt32; i++ {
+			j := i + int(r.Uint64n(uint64(n-i)))
+			s[i], s[j] = s[j], s[i]
+		}
+		for ; i < k; i++ {
+			j := i + int(r.Uint32n(uint32(n-i)))
+			s[i], s[j] = s[j], s[i]
+		}
+	}
+}
+
+// Choice returns a pseudo-randomly chosen element of s, or false if s is empty.
+//
+// When r is nil, Choice uses non-deterministic goroutine-local
+// pseudo-random data source, and is safe for concurrent use from multiple goroutines.
+func Choice[S ~[]E, E any](r *Rand, s S) (E, bool) {
+	if len(s) == 0 {
+		var zero E
+		return zero, false
+	}
+	return s[intn(r, len(s))], true
+}
+
+// WeightedChoice returns a pseudo-randomly chosen element of items, where the probability
+// of choosing items[i] is proportional to weights[i]. It returns false if items is empty
+// or all weights are zero. WeightedChoice panics if len(weights) != len(items)
+// or any of the weights is negative, infinite or NaN.
+//
+// WeightedChoice performs a single linear scan of weights. To repeatedly choose from the same
+// items, consider precomputing cumulative weights and using binary search instead.
+//
+// When r is nil, WeightedChoice uses non-deterministic goroutine-local
+// pseudo-random data source, and is safe for concurrent use from multiple goroutines.
+func WeightedChoice[S ~[]E, E any](r *Rand, items S, weights []float64) (E, bool) {
+	var zero E
+	if len(weights) != len(items) {
+		panic("invalid argument to WeightedChoice")
+	}
+	total, largest := 0.0, 0.0
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			panic("invalid argument to WeightedChoice")
+		}
+		total += w
+		if w > largest {
+			largest = w
+		}
+	}
+	if total == 0 {
+		return zero, false
+	}
+	scale := 1.0
+	if math.IsInf(total, 1) {
+		// finite weights with a sum that overflows
+		scale = largest
+		total = 0
+		for _, w := range weights {
+			total += w / scale
+		}
+	}
+	var u float64
+	if r == nil {
+		u = Float64() * total
+	} else {
+		u = r.Float64() * total
+	}
+	last := -1
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		w /= scale
+		if u < w {
+			return items[i], true
+		}
+		u -= w
+		last = i
+	}
+	return items[last], true // floating-point rounding
+}
+
+// ChoiceN returns a slice of n pseudo-randomly chosen elements of s. When replace is true,
+// elements are chosen independently and can repeat; otherwise, every element of s is chosen
+// at most once. ChoiceN panics if n < 0, or if n > 0 and s is empty with replace set,
+// or if n > len(s) without replace. Without replace, ChoiceN runs in O(n) time and memory
+// when n is much smaller than len(s), and in O(len(s)) otherwise.
+//
+// When r is nil, ChoiceN uses non-deterministic goroutine-local
+// pseudo-random data source, and is safe for concurrent use from multiple goroutines.
+func ChoiceN[S ~[]E, E any](r *Rand, s S, n int, replace bool) S {
+	if n < 0 || (replace && n > 0 && len(s) == 0) || (!replace && n > len(s)) {
+		panic("invalid argument to ChoiceN")
+	}
+	if replace {
+		c := make(S, n)
+		for i := range c {
+			c[i] = s[intn(r, len(s))]
+		}
+		return c
+	}
+	if n <= len(s)/choiceNSparseRatio {
+		// avoid copying all of s when choosing only a few elements
+		c := make(S, n)
+		for i, j := range shufflePrefixIndices(r, len(s), n) {
+			c[i] = s[j]
+		}
+		return c
+	}
+	c := append(S(nil), s...)
+	ShuffleSlicePrefix(r, c, n)
+	return c[:n:n]
+}
+
+// shufflePrefixIndices returns the first k elements of the identity permutation of size n
+// after ShuffleSlicePrefix (with the same pseudo-random draws), in O(k) time and memory.
+func shufflePrefixIndices(r *Rand, n int, k int) []int {
+	idx := make([]int, k)
+	swapped := make(map[int]int, k)
+	at := func(i int) int {
+		if v, ok := swapped[i]; ok {
+			return v
+		}
+		return i
+	}
+	for i := range idx {
+		var j int
+		switch {
+		case n-i > math.MaxInt32 && r == nil:
+			j = i + int(Uint64n(uint64(n-i)))
+		case n-i > math.MaxInt32:
+			j = i + int(r.Uint64n(uint64(n-i)))
+		case r == nil:
+			j = i + int(Uint32n(uint32(n-i)))
+		default:
+			j = i + int(r.Uint32n(uint32(n-i)))
+		}
+		idx[i] = at(j)
+		swapped[j] = at(i)
+	}
+	return idx
+}
+
+// choiceNSparseRatio is the minimum ratio of len(s) to n for ChoiceN without replacement
+// to track swapped indices in a map instead of shuffling a copy of s.
+const choiceNSparseRatio = 16
+
+func intn(r *Rand, n int) int {
+	if r == nil {
+		return Intn(n)
+	}
+	return r.Intn(n)
+}
@@ -10,6 +10,7 @@ package rand_test
 
 import (
 	"bytes"
+	"math"
 	"pgregory.net/rand"
 	"pgregory.net/rapid"
 	"testing"
@@ -42,3 +43,169 @@ func TestShuffleSlice(t *testing.T) {
 		}
 	})
 }
+
+func TestShuffleSlicePrefix(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		var r *rand.Rand
+		if rapid.Bool().Draw(t, "seeded").(bool) {
+			r = rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		}
+		n := rapid.IntRange(0, small).Draw(t, "n").(int)
+		k := rapid.IntRange(0, n).Draw(t, "k").(int)
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i
+		}
+		rand.ShuffleSlicePrefix(r, s, k)
+		seen := make([]bool, n)
+		for _, v := range s {
+			if seen[v] {
+				t.Fatalf("got %v more than once", v)
+			}
+			seen[v] = true
+		}
+	})
+}
+
+func TestChoice(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		var r *rand.Rand
+		if rapid.Bool().Draw(t, "seeded").(bool) {
+			r = rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		}
+		s := rapid.SliceOfN(rapid.Int(), 0, small).Draw(t, "s").([]int)
+		v, ok := rand.Choice(r, s)
+		if ok != (len(s) > 0) {
+			t.Fatalf("got ok %v for slice of length %v", ok, len(s))
+		}
+		if ok && !containsInt(s, v) {
+			t.Fatalf("got %v not from %v", v, s)
+		}
+	})
+}
+
+func TestWeightedChoice(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		var r *rand.Rand
+		if rapid.Bool().Draw(t, "seeded").(bool) {
+			r = rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		}
+		weights := rapid.SliceOfN(rapid.SampledFrom([]float64{0, 0.5, 1, 1e-300, 1e300}), 0, small).Draw(t, "weights").([]float64)
+		items := make([]int, len(weights))
+		total := 0.0
+		for i, w := range weights {
+			items[i] = i
+			total += w
+		}
+		i, ok := rand.WeightedChoice(r, items, weights)
+		if ok != (total > 0) {
+			t.Fatalf("got ok %v for total weight %v", ok, total)
+		}
+		if ok && weights[i] == 0 {
+			t.Fatalf("got item %v with zero weight", i)
+		}
+	})
+}
+
+func TestWeightedChoice_Frequencies(t *testing.T) {
+	const N = 100000
+	r := rand.New(1)
+	items := []string{"a", "b", "c", "d"}
+	weights := []float64{1, 0, 3, 6}
+	counts := map[string]int{}
+	for i := 0; i < N; i++ {
+		v, _ := rand.WeightedChoice(r, items, weights)
+		counts[v]++
+	}
+	for i, item := range items {
+		expected := weights[i] / 10 * N
+		if math.Abs(float64(counts[item])-expected) > 4*math.Sqrt(N)+1 {
+			t.Errorf("item %q chosen %v times, expected %v", item, counts[item], expected)
+		}
+	}
+}
+
+func TestChoiceN_Sparse(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		seed := rapid.Uint64().Draw(t, "s").(uint64)
+		m := rapid.IntRange(0, 10000).Draw(t, "m").(int)
+		n := rapid.IntRange(0, m).Draw(t, "n").(int)
+		s := make([]int, m)
+		for i := range s {
+			s[i] = i
+		}
+		c := rand.ChoiceN(rand.New(seed), s, n, false)
+		rand.ShuffleSlicePrefix(rand.New(seed), s, n)
+		for i, v := range c {
+			if v != s[i] {
+				t.Fatalf("got %v instead of %v at position %v", c, s[:n], i)
+			}
+		}
+	})
+}
+
+func BenchmarkChoiceN_Few(b *testing.B) {
+	r := rand.New(1)
+	s := make([]int, 1<<20)
+	for i := 0; i < b.N; i++ {
+		rand.ChoiceN(r, s, 3, false)
+	}
+}
+
+func TestWeightedChoice_Overflow(t *testing.T) {
+	const N = 10000
+	r := rand.New(1)
+	items := []string{"a", "b", "c"}
+	weights := []float64{1e308, 1e308, 1}
+	counts := map[string]int{}
+	for i := 0; i < N; i++ {
+		v, _ := rand.WeightedChoice(r, items, weights)
+		counts[v]++
+	}
+	if counts["c"] != 0 || math.Abs(float64(counts["a"])-N/2) > 4*math.Sqrt(N) {
+		t.Errorf("got counts %v for weights %v", counts, weights)
+	}
+}
+
+func TestChoiceN(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		var r *rand.Rand
+		if rapid.Bool().Draw(t, "seeded").(bool) {
+			r = rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		}
+		m := rapid.IntRange(0, small).Draw(t, "m").(int)
+		s := make([]int, m)
+		for i := range s {
+			s[i] = i
+		}
+		replace := rapid.Bool().Draw(t, "replace").(bool)
+		maxN := m
+		if replace && m > 0 {
+			maxN = small
+		}
+		n := rapid.IntRange(0, maxN).Draw(t, "n").(int)
+		c := rand.ChoiceN(r, s, n, replace)
+		if len(c) != n {
+			t.Fatalf("got %v elements instead of %v", len(c), n)
+		}
+		seen := make([]bool, m)
+		for _, v := range c {
+			if v < 0 || v >= m {
+				t.Fatalf("got %v outside of [0, %v)", v, m)
+			}
+			if seen[v] && !replace {
+				t.Fatalf("got %v more than once without replacement", v)
+			}
+			seen[v] = true
+		}
+	})
+}
+
+func containsInt(s []int, v int) bool {
+	for _, u := range s {
+		if u == v {
+			return true
+		}
+	}
+	return false
+}
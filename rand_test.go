@@ -117,6 +117,22 @@ func TestRand_Uint64n(t *testing.T) {
 	})
 }
 
+func TestRand_Uint128n(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		hi := rapid.Uint64().Draw(t, "hi").(uint64)
+		lo := rapid.Uint64().Draw(t, "lo").(uint64)
+		if hi == 0 && lo == 0 {
+			lo = 1
+		}
+		vh, vl := r.Uint128n(hi, lo)
+		if vh > hi || (vh == hi && vl >= lo) {
+			t.Fatalf("got (%v, %v) outside of [0, (%v, %v))", vh, vl, hi, lo)
+		}
+	})
+}
+
 func TestRand_MarshalBinary_Roundtrip(t *testing.T) {
 	rapid.Check(t, func(t *rapid.T) {
 		s := rapid.Uint64().Draw(t, "s").(uint64)
@@ -140,6 +156,22 @@ func TestRand_MarshalBinary_Roundtrip(t *testing.T) {
 	})
 }
 
+func TestRand_PermInto(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		dst := rapid.SliceOfN(rapid.Int(), 0, small).Draw(t, "dst").([]int)
+		r.PermInto(dst)
+		r.Seed(s)
+		p := r.Perm(len(dst))
+		for i := range p {
+			if dst[i] != p[i] {
+				t.Fatalf("got %v instead of %v", dst, p)
+			}
+		}
+	})
+}
+
 func TestRand_Uint32nOpt(t *testing.T) {
 	rapid.Check(t, func(t *rapid.T) {
 		n := rapid.Uint32().Draw(t, "n").(uint32)
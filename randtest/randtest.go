@@ -0,0 +1,134 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package randtest implements goodness-of-fit tests for checking pseudo-random samplers.
+//
+// Every test returns a p-value: a probability of observing a test statistic at least as extreme
+// when samples do come from the specified distribution. Use [Check] to fail a test when a p-value
+// is too small. Since p-values of correct samplers are uniformly distributed, tests with
+// a fixed seed and a small significance level are both reproducible and robust.
+package randtest
+
+import (
+	"math"
+	"pgregory.net/rand/internal/stats"
+	"sort"
+	"testing"
+)
+
+// DefaultAlpha is a significance level suitable for most tests.
+const DefaultAlpha = 1e-6
+
+// Check reports a test error when p is less than alpha.
+func Check(tb testing.TB, name string, p float64, alpha float64) {
+	tb.Helper()
+	if !(p >= alpha) {
+		tb.Errorf("%v: p-value %.3g is less than %.3g", name, p, alpha)
+	}
+}
+
+// KS returns the p-value of the one-sample Kolmogorov–Smirnov test of samples against
+// a continuous cumulative distribution function cdf. It sorts samples in place.
+// It panics if there are no samples.
+func KS(samples []float64, cdf func(float64) float64) float64 {
+	if len(samples) == 0 {
+		panic("invalid argument to KS")
+	}
+	sort.Float64s(samples)
+	n := float64(len(samples))
+	d := 0.0
+	for i, x := range samples {
+		f := cdf(x)
+		d = math.Max(d, math.Max(f-float64(i)/n, float64(i+1)/n-f))
+	}
+	// Stephens' correction for finite sample size
+	sn := math.Sqrt(n)
+	return stats.KolmogorovSF((sn + 0.12 + 0.11/sn) * d)
+}
+
+// AndersonDarling returns the p-value of the Anderson–Darling test of samples against
+// a continuous cumulative distribution function cdf. Compared to [KS], it is more sensitive
+// to deviations in the tails of the distribution. It sorts samples in place.
+// It panics if there are no samples.
+func AndersonDarling(samples []float64, cdf func(float64) float64) float64 {
+	if len(samples) == 0 {
+		panic("invalid argument to AndersonDarling")
+	}
+	sort.Float64s(samples)
+	n := len(samples)
+	s := 0.0
+	for i, x := range samples {
+		lo := cdf(x)
+		hi := 1 - cdf(samples[n-1-i])
+		if lo <= 0 || hi <= 0 {
+			return 0 // sample outside of the support
+		}
+		s += float64(2*i+1) * (math.Log(lo) + math.Log(hi))
+	}
+	a2 := -float64(n) - s/float64(n)
+	return stats.AndersonDarlingSF(a2, n)
+}
+
+// ChiSquare returns the p-value of Pearson's chi-squared test of counts of observations in categories
+// against probabilities of the categories, which must sum to 1. For the test to be accurate,
+// expected counts should be at least 5; combine rare categories to achieve that.
+// It panics if lengths of counts and probabilities differ, or there are less than 2 categories.
+func ChiSquare(counts []int, probabilities []float64) float64 {
+	if len(counts) != len(probabilities) || len(counts) < 2 {
+		panic("invalid argument to ChiSquare")
+	}
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	var χ2 float64
+	for i, c := range counts {
+		want := float64(total) * probabilities[i]
+		if want == 0 {
+			if c != 0 {
+				return 0 // impossible observation
+			}
+			continue
+		}
+		d := float64(c) - want
+		χ2 += d * d / want
+	}
+	return stats.ChiSquareSF(χ2, float64(len(counts)-1))
+}
+
+// Moments returns the p-value of the test that the raw moments E[X], E[X²], …, E[X^k] of the distribution
+// of samples are equal to moments[0], moments[1], …, moments[k-1]. Each moment is checked with a z-test
+// (using the sample variance of X^i), and p-values are combined using the Bonferroni correction.
+// Moments of order i are only checked reliably when the distribution has a finite moment of order 2i.
+// It panics if there are less than 2 samples or no moments.
+func Moments(samples []float64, moments []float64) float64 {
+	if len(samples) < 2 || len(moments) == 0 {
+		panic("invalid argument to Moments")
+	}
+	n := float64(len(samples))
+	p := 1.0
+	for i, m := range moments {
+		sum, sumSq := 0.0, 0.0
+		for _, x := range samples {
+			v := math.Pow(x, float64(i+1))
+			sum += v
+			sumSq += v * v
+		}
+		mean := sum / n
+		variance := (sumSq - sum*mean) / (n - 1)
+		var pi float64
+		switch {
+		case variance > 0:
+			pi = stats.NormalTwoSided((mean - m) / math.Sqrt(variance/n))
+		case mean == m:
+			pi = 1
+		default:
+			pi = 0
+		}
+		p = math.Min(p, pi)
+	}
+	return math.Min(1, p*float64(len(moments)))
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package randtest_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rand/randtest"
+	"testing"
+)
+
+const numSamples = 10000
+
+func normalCDF(mu float64) func(float64) float64 {
+	return func(x float64) float64 { return 0.5 * math.Erfc(-(x-mu)/math.Sqrt2) }
+}
+
+func expCDF(rate float64) func(float64) float64 {
+	return func(x float64) float64 { return -math.Expm1(-rate * x) }
+}
+
+func normSamples(seed uint64) []float64 {
+	r := rand.New(seed)
+	s := make([]float64, numSamples)
+	for i := range s {
+		s[i] = r.NormFloat64()
+	}
+	return s
+}
+
+func expSamples(seed uint64) []float64 {
+	r := rand.New(seed)
+	s := make([]float64, numSamples)
+	for i := range s {
+		s[i] = r.ExpFloat64()
+	}
+	return s
+}
+
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper()                                   {}
+func (r *recorder) Errorf(format string, args ...interface{}) { r.failed = true }
+
+func TestCheck(t *testing.T) {
+	for _, c := range []struct {
+		p    float64
+		fail bool
+	}{{0.5, false}, {randtest.DefaultAlpha, false}, {randtest.DefaultAlpha / 2, true}, {0, true}, {math.NaN(), true}} {
+		r := &recorder{}
+		randtest.Check(r, "test", c.p, randtest.DefaultAlpha)
+		if r.failed != c.fail {
+			t.Errorf("Check with p-value %v: failed %v", c.p, r.failed)
+		}
+	}
+}
+
+func TestKS(t *testing.T) {
+	randtest.Check(t, "normal", randtest.KS(normSamples(1), normalCDF(0)), randtest.DefaultAlpha)
+	randtest.Check(t, "exponential", randtest.KS(expSamples(1), expCDF(1)), randtest.DefaultAlpha)
+	if p := randtest.KS(normSamples(1), normalCDF(0.1)); p > randtest.DefaultAlpha {
+		t.Errorf("shifted normal distribution not detected: p-value %v", p)
+	}
+}
+
+func TestAndersonDarling(t *testing.T) {
+	randtest.Check(t, "normal", randtest.AndersonDarling(normSamples(1), normalCDF(0)), randtest.DefaultAlpha)
+	randtest.Check(t, "exponential", randtest.AndersonDarling(expSamples(1), expCDF(1)), randtest.DefaultAlpha)
+	if p := randtest.AndersonDarling(expSamples(1), expCDF(1.1)); p > randtest.DefaultAlpha {
+		t.Errorf("wrong exponential rate not detected: p-value %v", p)
+	}
+	if p := randtest.AndersonDarling(expSamples(1), normalCDF(1)); p != 0 {
+		t.Errorf("samples outside of the support not detected: p-value %v", p)
+	}
+}
+
+func TestChiSquare(t *testing.T) {
+	r := rand.New(1)
+	fair := make([]int, 6)
+	biased := make([]int, 6)
+	for i := 0; i < numSamples; i++ {
+		fair[r.Intn(6)]++
+		biased[r.Intn(7)%6]++
+	}
+	probabilities := []float64{1 / 6.0, 1 / 6.0, 1 / 6.0, 1 / 6.0, 1 / 6.0, 1 / 6.0}
+	randtest.Check(t, "fair die", randtest.ChiSquare(fair, probabilities), randtest.DefaultAlpha)
+	if p := randtest.ChiSquare(biased, probabilities); p > randtest.DefaultAlpha {
+		t.Errorf("biased die not detected: p-value %v", p)
+	}
+	if p := randtest.ChiSquare([]int{1, 1}, []float64{1, 0}); p != 0 {
+		t.Errorf("impossible observation not detected: p-value %v", p)
+	}
+}
+
+func TestMoments(t *testing.T) {
+	// raw moments of the exponential distribution are k!
+	randtest.Check(t, "exponential", randtest.Moments(expSamples(1), []float64{1, 2, 6}), randtest.DefaultAlpha)
+	randtest.Check(t, "normal", randtest.Moments(normSamples(1), []float64{0, 1, 0, 3}), randtest.DefaultAlpha)
+	if p := randtest.Moments(normSamples(1), []float64{0, 1.2}); p > randtest.DefaultAlpha {
+		t.Errorf("wrong variance not detected: p-value %v", p)
+	}
+}
+
+func TestUniformPValues(t *testing.T) {
+	// p-values of correct samples are uniformly distributed
+	tests := []struct {
+		name string
+		p    func(s []float64) float64
+	}{
+		{"KS", func(s []float64) float64 { return randtest.KS(s, normalCDF(0)) }},
+		{"AndersonDarling", func(s []float64) float64 { return randtest.AndersonDarling(s, normalCDF(0)) }},
+		{"Moments", func(s []float64) float64 { return randtest.Moments(s, []float64{0}) }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			const reps, n = 1000, 100
+			r := rand.New(1)
+			s := make([]float64, n)
+			counts := make([]int, 10)
+			for i := 0; i < reps; i++ {
+				for j := range s {
+					s[j] = r.NormFloat64()
+				}
+				counts[int(math.Min(test.p(s)*10, 9))]++
+			}
+			probabilities := []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1}
+			randtest.Check(t, "p-values", randtest.ChiSquare(counts, probabilities), 1e-4)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Package rapidrand implements [rapid] generators of [rand.Rand] values,
+// for property-based testing of code that accepts a [rand.Rand].
+//
+// The package requires the randfuzz build tag (go test -tags=randfuzz). Every 64-bit output
+// of a generated [rand.Rand] is drawn from the rapid bitstream, so rapid can shrink
+// failing test cases: outputs shrink towards zero, and methods like [rand.Rand.Intn]
+// towards smallest results.
+package rapidrand
+
+import (
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+)
+
+// Rand returns a generator of *rand.Rand values, which take their outputs from
+// the bitstream of the property test they have been drawn in. Generated values must not be
+// used after the property function returns. Rand panics if the randfuzz build tag is not specified.
+func Rand() *rapid.Generator {
+	if !randfuzz {
+		panic("rapidrand requires randfuzz build tag (go test -tags=randfuzz)")
+	}
+	return rapid.Custom(func(t *rapid.T) *rand.Rand {
+		g := rapid.Uint64()
+		// rapid requires custom generators to draw data, so the first output is drawn eagerly
+		first, drawn := g.Draw(t, "").(uint64), false
+		return rand.FromFunc(func() uint64 {
+			if !drawn {
+				drawn = true
+				return first
+			}
+			return g.Draw(t, "").(uint64)
+		})
+	})
+}
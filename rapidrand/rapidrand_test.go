@@ -0,0 +1,31 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+//go:build randfuzz
+
+package rapidrand_test
+
+import (
+	"pgregory.net/rand"
+	"pgregory.net/rand/rapidrand"
+	"pgregory.net/rapid"
+	"sort"
+	"testing"
+)
+
+func TestRand(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rapidrand.Rand().Draw(t, "r").(*rand.Rand)
+		n := r.Intn(100) + 1
+		p := r.Perm(n)
+		sort.Ints(p)
+		for i, v := range p {
+			if v != i {
+				t.Fatalf("invalid permutation of %v elements", n)
+			}
+		}
+	})
+}
@@ -1045,12 +1045,17 @@ func TestSFC64_NumPy_DEADBEAF(t *testing.T) {
 
 	var s sfc64
 	s.init(5778446405158232650, 4639759349701729399, 13222832537653397986)
+	r := NewNumPy(0xdeadbeaf)
 
 	for i, u := range golden {
 		v := s.next64()
 		if v != u {
 			t.Fatalf("got %v instead of %v at step %v", v, u, i)
 		}
+		v = r.Uint64()
+		if v != u {
+			t.Fatalf("NewNumPy: got %v instead of %v at step %v", v, u, i)
+		}
 	}
 }
 
@@ -2060,11 +2065,16 @@ func TestSFC64_NumPy_0(t *testing.T) {
 
 	var s sfc64
 	s.init(15793235383387715774, 12390638538380655177, 2361836109651742017)
+	r := NewNumPy(0)
 
 	for i, u := range golden {
 		v := s.next64()
 		if v != u {
 			t.Fatalf("got %v instead of %v at step %v", v, u, i)
 		}
+		v = r.Uint64()
+		if v != u {
+			t.Fatalf("NewNumPy: got %v instead of %v at step %v", v, u, i)
+		}
 	}
 }
@@ -10,4 +10,5 @@ package rand
 
 func init() {
 	ShuffleSliceGeneric = func(r *Rand, s []int) { ShuffleSlice(r, s) }
+	ShuffleSlicePrefixGeneric = func(r *Rand, s []int, k int) { ShuffleSlicePrefix(r, s, k) }
 }
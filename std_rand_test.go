@@ -550,6 +550,21 @@ func TestUniformFactorial(t *testing.T) {
 					ShuffleSliceGeneric(r, p)
 					return encodePerm(p)
 				}},
+				{name: "ShuffleSlicePrefixGeneric", fn: func() int {
+					if ShuffleSlicePrefixGeneric == nil {
+						return int(r.Uint32n(uint32(nfact)))
+					}
+					// Generate permutation using generic partial Shuffle.
+					for i := range p {
+						p[i] = i
+					}
+					ShuffleSlicePrefixGeneric(r, p, n-1)
+					return encodePerm(p)
+				}},
+				{name: "PermInto", fn: func() int {
+					r.PermInto(p)
+					return encodePerm(p)
+				}},
 			}
 
 			for _, test := range tests {
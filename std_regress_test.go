@@ -24,6 +24,32 @@ var (
 	skipregress = flag.Bool("skipregress", false, "skip the regression test")
 )
 
+// regressSkip contains methods that are not part of the math/rand API
+// covered by the golden outputs; they are tested separately.
+var regressSkip = map[string]bool{
+	"Get":              true,
+	"Seed":             true,
+	"UnmarshalBinary":  true,
+	"BigFloat":         true,
+	"BigIntn":          true,
+	"Uint128n":         true,
+	"Duration":         true,
+	"Time":             true,
+	"PermInto":         true,
+	"Dirichlet":        true,
+	"Multinomial":      true,
+	"Pareto":           true,
+	"LogNormal":        true,
+	"Weibull":          true,
+	"Cauchy":           true,
+	"Levy":             true,
+	"TruncExpFloat64":  true,
+	"TruncNormFloat64": true,
+	"InverseCDF":       true,
+	"NormFloat32":      true,
+	"ExpFloat32":       true,
+}
+
 func TestRegress(t *testing.T) {
 	if *skipregress {
 		t.Skip("-skipregress specified")
@@ -48,7 +74,7 @@ func TestRegress(t *testing.T) {
 		m := rv.Type().Method(i)
 		mv := rv.Method(i)
 		mt := mv.Type()
-		if m.Name == "Get" || m.Name == "Seed" || m.Name == "UnmarshalBinary" {
+		if regressSkip[m.Name] {
 			continue
 		}
 		for repeat := 0; repeat < 17; repeat++ {
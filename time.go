@@ -0,0 +1,73 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import (
+	"math/bits"
+	"time"
+)
+
+const nsPerSec = uint64(time.Second)
+
+// Duration returns, as a time.Duration, a uniformly distributed pseudo-random duration
+// in the half-open interval [min, max). It panics if max <= min.
+func (r *Rand) Duration(min time.Duration, max time.Duration) time.Duration {
+	if max <= min {
+		panic("invalid argument to Duration")
+	}
+	return min + time.Duration(r.Uint64n(uint64(max)-uint64(min)))
+}
+
+// Time returns a uniformly distributed pseudo-random time in the half-open interval [start, end),
+// with nanosecond resolution and in the location of start. It panics if end is not after start.
+func (r *Rand) Time(start time.Time, end time.Time) time.Time {
+	if !end.After(start) {
+		panic("invalid argument to Time")
+	}
+	hi, lo := timeSpan(start, end)
+	hi, lo = r.Uint128n(hi, lo)
+	return timeAdd(start, hi, lo)
+}
+
+// Duration returns, as a time.Duration, a uniformly distributed pseudo-random duration
+// in the half-open interval [min, max). It panics if max <= min.
+func Duration(min time.Duration, max time.Duration) time.Duration {
+	if max <= min {
+		panic("invalid argument to Duration")
+	}
+	return min + time.Duration(Uint64n(uint64(max)-uint64(min)))
+}
+
+// Time returns a uniformly distributed pseudo-random time in the half-open interval [start, end),
+// with nanosecond resolution and in the location of start. It panics if end is not after start.
+func Time(start time.Time, end time.Time) time.Time {
+	if !end.After(start) {
+		panic("invalid argument to Time")
+	}
+	hi, lo := timeSpan(start, end)
+	hi, lo = Uint128n(hi, lo)
+	return timeAdd(start, hi, lo)
+}
+
+// timeSpan returns the number of nanoseconds between start and end as a 128-bit number;
+// unlike end.Sub(start), it does not saturate for spans longer than ~292 years.
+func timeSpan(start time.Time, end time.Time) (uint64, uint64) {
+	sec := uint64(end.Unix()) - uint64(start.Unix())
+	nsec := int64(end.Nanosecond()) - int64(start.Nanosecond())
+	if nsec < 0 {
+		sec--
+		nsec += int64(nsPerSec)
+	}
+	hi, lo := bits.Mul64(sec, nsPerSec)
+	lo, carry := bits.Add64(lo, uint64(nsec), 0)
+	return hi + carry, lo
+}
+
+func timeAdd(t time.Time, hi uint64, lo uint64) time.Time {
+	sec, nsec := bits.Div64(hi, lo, nsPerSec)
+	return time.Unix(t.Unix()+int64(sec), int64(t.Nanosecond())+int64(nsec)).In(t.Location())
+}
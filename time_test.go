@@ -0,0 +1,70 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"testing"
+	"time"
+)
+
+func TestRand_Duration(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		min := time.Duration(rapid.Int64Max(math.MaxInt64-1).Draw(t, "min").(int64))
+		max := time.Duration(rapid.Int64Min(int64(min)+1).Draw(t, "max").(int64))
+		d := r.Duration(min, max)
+		if d < min || d >= max {
+			t.Fatalf("got %v outside of [%v, %v)", d, min, max)
+		}
+	})
+}
+
+func TestRand_Time(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		start := time.Unix(rapid.Int64Range(-1<<60, 1<<60).Draw(t, "start_sec").(int64), rapid.Int64Range(0, 1e9-1).Draw(t, "start_nsec").(int64))
+		end := time.Unix(rapid.Int64Range(-1<<60, 1<<60).Draw(t, "end_sec").(int64), rapid.Int64Range(0, 1e9-1).Draw(t, "end_nsec").(int64))
+		if !end.After(start) {
+			start, end = end, start.Add(1)
+		}
+		v := r.Time(start, end)
+		if v.Before(start) || !v.Before(end) {
+			t.Fatalf("got %v outside of [%v, %v)", v, start, end)
+		}
+	})
+}
+
+func TestRand_Time_Duration(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := rapid.Uint64().Draw(t, "s").(uint64)
+		r := rand.New(s)
+		start := time.Unix(0, rapid.Int64().Draw(t, "start").(int64))
+		d := time.Duration(rapid.Int64Min(1).Draw(t, "d").(int64))
+		v1 := r.Time(start, start.Add(d))
+		r.Seed(s)
+		v2 := start.Add(r.Duration(0, d))
+		if !v1.Equal(v2) {
+			t.Fatalf("got %v instead of %v", v1, v2)
+		}
+	})
+}
+
+func TestDuration(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		min := time.Duration(rapid.Int64Max(math.MaxInt64-1).Draw(t, "min").(int64))
+		max := time.Duration(rapid.Int64Min(int64(min)+1).Draw(t, "max").(int64))
+		d := rand.Duration(min, max)
+		if d < min || d >= max {
+			t.Fatalf("got %v outside of [%v, %v)", d, min, max)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math"
+
+const sqrt2Pi = 2.5066282746310002
+
+// TruncNormFloat64 returns a float64 from the normal distribution with mean mu and standard deviation sigma,
+// truncated to the interval [lo, hi]. Either bound can be infinite. TruncNormFloat64 is exact and
+// efficient even for intervals far in the tails, where naive rejection of [Rand.NormFloat64] samples
+// would take forever. It panics if sigma <= 0 or lo >= hi.
+func (r *Rand) TruncNormFloat64(mu float64, sigma float64, lo float64, hi float64) float64 {
+	if !(sigma > 0) || !(lo < hi) {
+		panic("invalid argument to TruncNormFloat64")
+	}
+	a := (lo - mu) / sigma
+	b := (hi - mu) / sigma
+	if a < -b {
+		// sample from the mirrored interval, so that most of the mass is on the positive side
+		return mu - sigma*r.truncNorm(-b, -a)
+	}
+	return mu + sigma*r.truncNorm(a, b)
+}
+
+// truncNorm samples standard normal distribution truncated to [a, b], where a >= -b.
+func (r *Rand) truncNorm(a float64, b float64) float64 {
+	// "Simulation of truncated normal variables" by Christian P. Robert,
+	// https://arxiv.org/abs/0907.4010
+	if a <= 0 {
+		if b-a >= sqrt2Pi {
+			// [a, b] contains a large part of the distribution, use the ziggurat
+			for {
+				z := r.NormFloat64()
+				if a <= z && z <= b {
+					return z
+				}
+			}
+		}
+		for {
+			z := a + (b-a)*r.Float64()
+			if r.Float64() <= math.Exp(-0.5*z*z) {
+				return z
+			}
+		}
+	}
+
+	// one-sided interval: choose between uniform and translated exponential proposals
+	s := math.Sqrt(a*a + 4)
+	if b-a < 2/(a+s)*math.Exp(0.5+0.25*(a*a-a*s)) {
+		for {
+			z := a + (b-a)*r.Float64()
+			if r.Float64() <= math.Exp(0.5*(a*a-z*z)) {
+				return z
+			}
+		}
+	}
+	lambda := 0.5 * (a + s)
+	for {
+		z := a + r.ExpFloat64()/lambda
+		if z > b {
+			continue
+		}
+		d := z - lambda
+		if r.Float64() <= math.Exp(-0.5*d*d) {
+			return z
+		}
+	}
+}
+
+// TruncExpFloat64 returns a float64 from the exponential distribution with the given rate parameter,
+// truncated to the interval [lo, hi], where hi can be +Inf. It panics if rate <= 0, lo < 0 or lo >= hi.
+func (r *Rand) TruncExpFloat64(rate float64, lo float64, hi float64) float64 {
+	if !(rate > 0) || !(lo >= 0) || !(lo < hi) {
+		panic("invalid argument to TruncExpFloat64")
+	}
+	// exponential distribution is memoryless, so [lo, hi] is the same as [0, hi - lo] shifted by lo
+	w := rate * (hi - lo)
+	if w >= 1 {
+		// acceptance probability is at least 1 - 1/e, use the ziggurat
+		for {
+			x := r.ExpFloat64()
+			if x <= w {
+				return lo + x/rate
+			}
+		}
+	}
+	return lo - math.Log1p(r.Float64()*math.Expm1(-w))/rate
+}
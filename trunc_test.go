@@ -0,0 +1,104 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"fmt"
+	"math"
+	"pgregory.net/rand"
+	"pgregory.net/rapid"
+	"sort"
+	"testing"
+)
+
+// checkCDF performs a Kolmogorov–Smirnov test of samples against cdf at 0.1% significance level.
+func checkCDF(t *testing.T, samples []float64, cdf func(float64) float64) {
+	t.Helper()
+	sort.Float64s(samples)
+	n := float64(len(samples))
+	d := 0.0
+	for i, x := range samples {
+		f := cdf(x)
+		d = math.Max(d, math.Max(f-float64(i)/n, float64(i+1)/n-f))
+	}
+	if d*math.Sqrt(n) > 1.95 {
+		t.Errorf("Kolmogorov–Smirnov statistic %v is too large for %v samples", d, n)
+	}
+}
+
+// normSF returns the survival function of the standard normal distribution.
+func normSF(x float64) float64 {
+	return 0.5 * math.Erfc(x/math.Sqrt2)
+}
+
+func TestRand_TruncNormFloat64(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		r := rand.New(rapid.Uint64().Draw(t, "s").(uint64))
+		mu := rapid.Float64Range(-100, 100).Draw(t, "mu").(float64)
+		sigma := rapid.Float64Range(0.01, 100).Draw(t, "sigma").(float64)
+		lo := mu + sigma*rapid.Float64Range(-50, 50).Draw(t, "lo").(float64)
+		hi := lo + sigma*rapid.Float64Range(0.001, 50).Draw(t, "width").(float64)
+		if rapid.Bool().Draw(t, "inf_lo").(bool) {
+			lo = math.Inf(-1)
+		}
+		if rapid.Bool().Draw(t, "inf_hi").(bool) {
+			hi = math.Inf(1)
+		}
+		x := r.TruncNormFloat64(mu, sigma, lo, hi)
+		if x < lo || x > hi {
+			t.Fatalf("got %v outside of [%v, %v]", x, lo, hi)
+		}
+	})
+}
+
+func TestRand_TruncNormFloat64Distribution(t *testing.T) {
+	const n = 20000
+	inf := math.Inf(1)
+	intervals := [][2]float64{
+		{-1, 1}, {-10, 0.5}, {-0.1, 5}, {-3, inf}, {0, inf}, {-inf, -2},
+		{0.1, 0.2}, {0.1, 50}, {2, inf}, {8, 9}, {8, 8.01}, {30, inf}, {-inf, -30},
+	}
+	for _, ab := range intervals {
+		a, b := ab[0], ab[1]
+		t.Run(fmt.Sprintf("[%v,%v]", a, b), func(t *testing.T) {
+			r := rand.New(uint64(testSeeds[0]))
+			samples := make([]float64, n)
+			for i := range samples {
+				samples[i] = r.TruncNormFloat64(0, 1, a, b)
+			}
+			if a < -b {
+				// compare mirrored samples to keep the CDF accurate in the tails
+				for i := range samples {
+					samples[i] = -samples[i]
+				}
+				a, b = -b, -a
+			}
+			qa, qb := normSF(a), normSF(b)
+			checkCDF(t, samples, func(x float64) float64 { return (qa - normSF(x)) / (qa - qb) })
+		})
+	}
+}
+
+func TestRand_TruncExpFloat64Distribution(t *testing.T) {
+	const n = 20000
+	tests := [][3]float64{{1, 0, 0.5}, {1, 0, 1}, {2, 1, math.Inf(1)}, {0.1, 0, 3}, {10, 5, 5.01}}
+	for _, test := range tests {
+		rate, lo, hi := test[0], test[1], test[2]
+		t.Run(fmt.Sprintf("%v[%v,%v]", rate, lo, hi), func(t *testing.T) {
+			r := rand.New(uint64(testSeeds[0]))
+			samples := make([]float64, n)
+			for i := range samples {
+				samples[i] = r.TruncExpFloat64(rate, lo, hi)
+				if samples[i] < lo || samples[i] > hi {
+					t.Fatalf("got %v outside of [%v, %v]", samples[i], lo, hi)
+				}
+			}
+			z := -math.Expm1(-rate * (hi - lo))
+			checkCDF(t, samples, func(x float64) float64 { return -math.Expm1(-rate*(x-lo)) / z })
+		})
+	}
+}
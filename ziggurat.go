@@ -0,0 +1,201 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math"
+
+const zigguratLayers = 256
+
+// ZigguratDensity describes a distribution for [NewZiggurat].
+type ZigguratDensity struct {
+	// PDF is the probability density function, which must be decreasing on [0, +Inf).
+	// It does not have to be normalized.
+	PDF func(x float64) float64
+	// InvPDF is the inverse of PDF on [0, +Inf). When nil, PDF is inverted numerically.
+	InvPDF func(y float64) float64
+	// TailArea returns the integral of PDF over [x, +Inf).
+	TailArea func(x float64) float64
+	// Tail returns a value from the distribution conditioned on being greater than x.
+	// Tail is called with the r passed to [NewZiggurat]; when it is nil, Tail should use
+	// the top-level functions (for example, [ExpFloat64] instead of [Rand.ExpFloat64]).
+	Tail func(r *Rand, x float64) float64
+	// Symmetric indicates that the distribution is symmetric around zero,
+	// with PDF(-x) == PDF(x); otherwise, the distribution is supported on [0, +Inf).
+	Symmetric bool
+}
+
+// A Ziggurat generates variates from a distribution with monotone decreasing density
+// using the ziggurat method, like [Rand.ExpFloat64] and [Rand.NormFloat64] do.
+type Ziggurat struct {
+	r    *Rand
+	d    ZigguratDensity
+	edge float64
+	k    [zigguratLayers]uint64
+	w    [zigguratLayers]float64
+	f    [zigguratLayers]float64
+}
+
+// NewZiggurat returns a Ziggurat variate generator for the distribution described by d,
+// building 256-layer ziggurat tables for it. It panics if d.PDF, d.TailArea or d.Tail is nil,
+// or if the tables can not be built because PDF is not decreasing.
+//
+// When r is nil, Ziggurat uses non-deterministic goroutine-local pseudo-random data source.
+//
+// See "The Ziggurat Method for Generating Random Variables" by George Marsaglia and Wai Wan Tsang,
+// https://www.jstatsoft.org/v05/i08/paper
+func NewZiggurat(r *Rand, d ZigguratDensity) *Ziggurat {
+	if d.PDF == nil || d.TailArea == nil || d.Tail == nil {
+		panic("invalid argument to NewZiggurat")
+	}
+	if d.InvPDF == nil {
+		d.InvPDF = func(y float64) float64 { return invertDecreasing(d.PDF, y) }
+	}
+	f0 := d.PDF(0)
+	if !(f0 > 0) || math.IsInf(f0, 1) {
+		panic("invalid argument to NewZiggurat")
+	}
+
+	// find the rightmost layer edge such that all layers have the same area
+	lo, hi := 0.0, 1.0
+	for zigguratTooNarrow(&d, f0, hi) {
+		lo, hi = hi, hi*2
+		if math.IsInf(hi, 1) {
+			panic("invalid argument to NewZiggurat")
+		}
+	}
+	for {
+		mid := lo + (hi-lo)/2
+		if mid <= lo || mid >= hi {
+			break
+		}
+		if zigguratTooNarrow(&d, f0, mid) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	z := &Ziggurat{r: r, d: d, edge: hi}
+	m := float64(1 << 53)
+	if d.Symmetric {
+		m = 1 << 52
+	}
+	// same as tables in std_exp.go and std_normal.go
+	x := z.edge
+	v := x*d.PDF(x) + d.TailArea(x)
+	q := v / d.PDF(x)
+	z.k[0] = uint64((x / q) * m)
+	z.k[1] = 0
+	z.w[0] = q / m
+	z.w[zigguratLayers-1] = x / m
+	z.f[0] = f0
+	z.f[zigguratLayers-1] = d.PDF(x)
+	for i := zigguratLayers - 2; i >= 1; i-- {
+		prev := x
+		x = d.InvPDF(v/x + d.PDF(x))
+		z.k[i+1] = uint64((x / prev) * m)
+		z.f[i] = d.PDF(x)
+		z.w[i] = x / m
+	}
+	return z
+}
+
+// zigguratTooNarrow reports whether the rightmost layer edge x is too close to zero,
+// which makes the area of layers too large to fit below the density.
+func zigguratTooNarrow(d *ZigguratDensity, f0 float64, x float64) bool {
+	v := x*d.PDF(x) + d.TailArea(x)
+	for i := zigguratLayers - 2; i >= 1; i-- {
+		y := v/x + d.PDF(x)
+		if y >= f0 {
+			return true
+		}
+		x = d.InvPDF(y)
+	}
+	return x*(f0-d.PDF(x)) < v
+}
+
+// invertDecreasing returns x >= 0 such that f(x) == y, for decreasing f.
+func invertDecreasing(f func(float64) float64, y float64) float64 {
+	lo, hi := 0.0, 1.0
+	for f(hi) > y {
+		lo, hi = hi, hi*2
+		if math.IsInf(hi, 1) {
+			return hi
+		}
+	}
+	for {
+		mid := lo + (hi-lo)/2
+		if mid <= lo || mid >= hi {
+			return mid
+		}
+		if f(mid) > y {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+}
+
+// Float64 returns a value drawn from the distribution described by the Ziggurat object.
+func (z *Ziggurat) Float64() float64 {
+	if z.d.Symmetric {
+		// see NormFloat64
+		for {
+			var v uint64
+			if z.r != nil {
+				v = z.r.Uint64()
+			} else {
+				v = rand64()
+			}
+			j := int64(v) >> 11
+			i := v & 0xFF
+			x := float64(j) * z.w[i]
+			if absInt64(j) < z.k[i] {
+				return x
+			}
+			if i == 0 {
+				x = z.d.Tail(z.r, z.edge)
+				if j > 0 {
+					return x
+				}
+				return -x
+			}
+			if z.f[i]+z.uniform()*(z.f[i-1]-z.f[i]) < z.d.PDF(math.Abs(x)) {
+				return x
+			}
+		}
+	}
+	// see ExpFloat64
+	for {
+		var v uint64
+		if z.r != nil {
+			v = z.r.Uint64()
+		} else {
+			v = rand64()
+		}
+		j := v >> 11
+		i := v & 0xFF
+		x := float64(j) * z.w[i]
+		if j < z.k[i] {
+			return x
+		}
+		if i == 0 {
+			return z.d.Tail(z.r, z.edge)
+		}
+		if z.f[i]+z.uniform()*(z.f[i-1]-z.f[i]) < z.d.PDF(x) {
+			return x
+		}
+	}
+}
+
+// uniform returns a float64 in [0, 1) from z.r, or from the goroutine-local source when z.r is nil.
+func (z *Ziggurat) uniform() float64 {
+	if z.r == nil {
+		return Float64()
+	}
+	return z.r.Float64()
+}
@@ -0,0 +1,161 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand
+
+import "math"
+
+// 32-bit versions of the ziggurat tables from std_exp.go and std_normal.go, with 24-bit (exponential)
+// and 23-bit plus sign (normal) fixed-point layer boundaries instead of 53-bit and 52-bit plus sign.
+var (
+	kn32, wn32, fn32 = tables32(&kn, &wn, &fn, 52-23)
+	ke32, we32, fe32 = tables32(&ke, &we, &fe, 53-24)
+)
+
+func tables32(k *[256]uint64, w *[256]float64, f *[256]float64, shift uint) (k32 [256]uint32, w32 [256]float32, f32 [256]float32) {
+	for i := range k {
+		k32[i] = uint32(k[i] >> shift)
+		w32[i] = float32(w[i] * float64(uint64(1)<<shift))
+		f32[i] = float32(f[i])
+	}
+	return
+}
+
+func absInt32(i int32) uint32 {
+	if i < 0 {
+		return uint32(-i)
+	}
+	return uint32(i)
+}
+
+// NormFloat32 returns a normally distributed float32 in
+// the range -math.MaxFloat32 through +math.MaxFloat32 inclusive,
+// with standard normal distribution (mean = 0, stddev = 1).
+// Unlike [Rand.NormFloat64], NormFloat32 usually consumes only 32 bits of pseudo-random data.
+// To produce a different normal distribution, callers can
+// adjust the output using:
+//
+//	sample = NormFloat32() * desiredStdDev + desiredMean
+func (r *Rand) NormFloat32() float32 {
+	for {
+		v := uint32(r.next32())
+		j := int32(v) >> 8 // Possibly negative
+		i := v & 0xFF
+		x := float32(j) * wn32[i]
+		if absInt32(j) < kn32[i] {
+			return x
+		}
+		if i == 0 {
+			return normTail32(j, r.float32Open)
+		}
+		if fn32[i]+r.Float32()*(fn32[i-1]-fn32[i]) < float32(math.Exp(-.5*float64(x)*float64(x))) {
+			return x
+		}
+	}
+}
+
+// ExpFloat32 returns an exponentially distributed float32 in the range
+// (0, +math.MaxFloat32] with an exponential distribution whose rate parameter
+// (lambda) is 1 and whose mean is 1/lambda (1).
+// Unlike [Rand.ExpFloat64], ExpFloat32 usually consumes only 32 bits of pseudo-random data.
+// To produce a distribution with a different rate parameter,
+// callers can adjust the output using:
+//
+//	sample = ExpFloat32() / desiredRateParameter
+func (r *Rand) ExpFloat32() float32 {
+	for {
+		v := uint32(r.next32())
+		j := v >> 8
+		i := v & 0xFF
+		x := float32(j) * we32[i]
+		if j < ke32[i] {
+			return x
+		}
+		if i == 0 {
+			return float32(re - math.Log(float64(r.float32Open())))
+		}
+		if fe32[i]+r.Float32()*(fe32[i-1]-fe32[i]) < float32(math.Exp(-float64(x))) {
+			return x
+		}
+	}
+}
+
+// float32Open returns a uniformly distributed float32 in the open interval (0.0, 1.0),
+// suitable for taking the logarithm.
+func (r *Rand) float32Open() float32 {
+	return (float32(r.next32()&int24Mask) + 0.5) * f24Mul
+}
+
+// NormFloat32 returns a normally distributed float32 in
+// the range -math.MaxFloat32 through +math.MaxFloat32 inclusive,
+// with standard normal distribution (mean = 0, stddev = 1).
+// To produce a different normal distribution, callers can
+// adjust the output using:
+//
+//	sample = NormFloat32() * desiredStdDev + desiredMean
+func NormFloat32() float32 {
+	for {
+		v := uint32(rand64())
+		j := int32(v) >> 8 // Possibly negative
+		i := v & 0xFF
+		x := float32(j) * wn32[i]
+		if absInt32(j) < kn32[i] {
+			return x
+		}
+		if i == 0 {
+			return normTail32(j, float32Open)
+		}
+		if fn32[i]+Float32()*(fn32[i-1]-fn32[i]) < float32(math.Exp(-.5*float64(x)*float64(x))) {
+			return x
+		}
+	}
+}
+
+// ExpFloat32 returns an exponentially distributed float32 in the range
+// (0, +math.MaxFloat32] with an exponential distribution whose rate parameter
+// (lambda) is 1 and whose mean is 1/lambda (1).
+// To produce a distribution with a different rate parameter,
+// callers can adjust the output using:
+//
+//	sample = ExpFloat32() / desiredRateParameter
+func ExpFloat32() float32 {
+	for {
+		v := uint32(rand64())
+		j := v >> 8
+		i := v & 0xFF
+		x := float32(j) * we32[i]
+		if j < ke32[i] {
+			return x
+		}
+		if i == 0 {
+			return float32(re - math.Log(float64(float32Open())))
+		}
+		if fe32[i]+Float32()*(fe32[i-1]-fe32[i]) < float32(math.Exp(-float64(x))) {
+			return x
+		}
+	}
+}
+
+func float32Open() float32 {
+	return (float32(rand64()&int24Mask) + 0.5) * f24Mul
+}
+
+// normTail32 samples the normal distribution tail beyond rn, with the sign of j.
+func normTail32(j int32, u func() float32) float32 {
+	// see NormFloat64
+	var x float64
+	for {
+		x = -math.Log(float64(u())) * (1.0 / rn)
+		y := -math.Log(float64(u()))
+		if y+y >= x*x {
+			break
+		}
+	}
+	if j > 0 {
+		return float32(rn + x)
+	}
+	return float32(-rn - x)
+}
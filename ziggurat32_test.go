@@ -0,0 +1,95 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	. "pgregory.net/rand"
+	"testing"
+)
+
+func BenchmarkRand_NormFloat32(b *testing.B) {
+	r := New(1)
+	var s float32
+	for i := 0; i < b.N; i++ {
+		s = r.NormFloat32()
+	}
+	sinkFloat32 = s
+}
+
+func BenchmarkRand_ExpFloat32(b *testing.B) {
+	r := New(1)
+	var s float32
+	for i := 0; i < b.N; i++ {
+		s = r.ExpFloat32()
+	}
+	sinkFloat32 = s
+}
+
+func TestNormTables32(t *testing.T) {
+	testKn, testWn, testFn := initNorm()
+	kn32, wn32, fn32 := GetNormalDistributionParameters32()
+	for i := range kn32 {
+		if k := testKn[i] >> (52 - 23); kn32[i] != uint32(k) {
+			t.Errorf("kn32 disagrees at index %v; %v != %v", i, kn32[i], k)
+		}
+		if w := testWn[i] * (1 << (52 - 23)); !nearEqual(float64(wn32[i]), w, 0, 1e-7) {
+			t.Errorf("wn32 disagrees at index %v; %v != %v", i, wn32[i], w)
+		}
+		if !nearEqual(float64(fn32[i]), testFn[i], 0, 1e-7) {
+			t.Errorf("fn32 disagrees at index %v; %v != %v", i, fn32[i], testFn[i])
+		}
+	}
+}
+
+func TestExpTables32(t *testing.T) {
+	testKe, testWe, testFe := initExp()
+	ke32, we32, fe32 := GetExponentialDistributionParameters32()
+	for i := range ke32 {
+		if k := testKe[i] >> (53 - 24); ke32[i] != uint32(k) {
+			t.Errorf("ke32 disagrees at index %v; %v != %v", i, ke32[i], k)
+		}
+		if w := testWe[i] * (1 << (53 - 24)); !nearEqual(float64(we32[i]), w, 0, 1e-7) {
+			t.Errorf("we32 disagrees at index %v; %v != %v", i, we32[i], w)
+		}
+		if !nearEqual(float64(fe32[i]), testFe[i], 0, 1e-7) {
+			t.Errorf("fe32 disagrees at index %v; %v != %v", i, fe32[i], testFe[i])
+		}
+	}
+}
+
+func TestNormFloat32Distribution(t *testing.T) {
+	const n = 20000
+	for _, seed := range testSeeds {
+		r := New(uint64(seed))
+		samples := make([]float64, n)
+		global := make([]float64, n)
+		for i := range samples {
+			samples[i] = float64(r.NormFloat32())
+			global[i] = float64(NormFloat32())
+		}
+		checkSampleDistribution(t, samples, &statsResults{0, 1, 0.10, 0.08})
+		checkCDF(t, samples, func(x float64) float64 { return 0.5 * math.Erfc(-x/math.Sqrt2) })
+		checkCDF(t, global, func(x float64) float64 { return 0.5 * math.Erfc(-x/math.Sqrt2) })
+	}
+}
+
+func TestExpFloat32Distribution(t *testing.T) {
+	const n = 20000
+	for _, seed := range testSeeds {
+		r := New(uint64(seed))
+		samples := make([]float64, n)
+		global := make([]float64, n)
+		for i := range samples {
+			samples[i] = float64(r.ExpFloat32())
+			global[i] = float64(ExpFloat32())
+		}
+		checkSampleDistribution(t, samples, &statsResults{1, 1, 0.10, 0.20})
+		checkCDF(t, samples, func(x float64) float64 { return -math.Expm1(-x) })
+		checkCDF(t, global, func(x float64) float64 { return -math.Expm1(-x) })
+	}
+}
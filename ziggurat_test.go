@@ -0,0 +1,120 @@
+// Copyright 2022 Gregory Petrosyan <gregory.petrosyan@gmail.com>
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+package rand_test
+
+import (
+	"math"
+	. "pgregory.net/rand"
+	"testing"
+)
+
+var (
+	expDensity = ZigguratDensity{
+		PDF:      func(x float64) float64 { return math.Exp(-x) },
+		InvPDF:   func(y float64) float64 { return -math.Log(y) },
+		TailArea: func(x float64) float64 { return math.Exp(-x) },
+		Tail:     func(r *Rand, x float64) float64 { return x + r.ExpFloat64() },
+	}
+	normDensity = ZigguratDensity{
+		PDF:      func(x float64) float64 { return math.Exp(-0.5 * x * x) },
+		TailArea: func(x float64) float64 { return math.Sqrt(math.Pi/2) * math.Erfc(x/math.Sqrt2) },
+		Tail:     func(r *Rand, x float64) float64 { return r.TruncNormFloat64(0, 1, x, math.Inf(1)) },
+		// InvPDF is left nil to test numeric inversion
+		Symmetric: true,
+	}
+	halfCauchyDensity = ZigguratDensity{
+		PDF:      func(x float64) float64 { return 1 / (1 + x*x) },
+		InvPDF:   func(y float64) float64 { return math.Sqrt(1/y - 1) },
+		TailArea: func(x float64) float64 { return math.Pi/2 - math.Atan(x) },
+		Tail: func(r *Rand, x float64) float64 {
+			u := Float64()
+			if r != nil {
+				u = r.Float64()
+			}
+			a := math.Atan(x)
+			return math.Tan(a + u*(math.Pi/2-a))
+		},
+	}
+)
+
+func BenchmarkZiggurat_Exp(b *testing.B) {
+	z := NewZiggurat(New(1), expDensity)
+	var s float64
+	for i := 0; i < b.N; i++ {
+		s = z.Float64()
+	}
+	sinkFloat64 = s
+}
+
+func TestZigguratExpTables(t *testing.T) {
+	testRe, testKe, testWe, testFe := GetZigguratParameters(NewZiggurat(New(1), expDensity))
+	if !nearEqual(testRe, re, 0, 1e-12) {
+		t.Errorf("re disagrees: %v != %v", re, testRe)
+	}
+	if i := compareUint64Slices(ke[0:], testKe[0:]); i >= 0 {
+		t.Errorf("ke disagrees at index %v; %v != %v", i, ke[i], testKe[i])
+	}
+	if i := compareFloat64Slices(we[0:], testWe[0:]); i >= 0 {
+		t.Errorf("we disagrees at index %v; %v != %v", i, we[i], testWe[i])
+	}
+	if i := compareFloat64Slices(fe[0:], testFe[0:]); i >= 0 {
+		t.Errorf("fe disagrees at index %v; %v != %v", i, fe[i], testFe[i])
+	}
+}
+
+func TestZigguratNormTables(t *testing.T) {
+	// std_normal.go tables are built from the area of layers rounded to 12 significant digits,
+	// and the narrow layers closest to zero amplify the rounding error
+	const maxError = 1e-9
+	testRn, testKn, testWn, testFn := GetZigguratParameters(NewZiggurat(New(1), normDensity))
+	if !nearEqual(testRn, rn, 0, 1e-12) {
+		t.Errorf("rn disagrees: %v != %v", rn, testRn)
+	}
+	for i := range kn {
+		if !nearEqual(float64(kn[i]), float64(testKn[i]), 0, maxError) {
+			t.Errorf("kn disagrees at index %v; %v != %v", i, kn[i], testKn[i])
+		}
+		if !nearEqual(wn[i], testWn[i], 0, maxError) {
+			t.Errorf("wn disagrees at index %v; %v != %v", i, wn[i], testWn[i])
+		}
+		if !nearEqual(fn[i], testFn[i], 0, maxError) {
+			t.Errorf("fn disagrees at index %v; %v != %v", i, fn[i], testFn[i])
+		}
+	}
+}
+
+func TestZigguratDistribution(t *testing.T) {
+	const n = 20000
+	tests := []struct {
+		name    string
+		density ZigguratDensity
+		cdf     func(float64) float64
+	}{
+		{"exp", expDensity, func(x float64) float64 { return -math.Expm1(-x) }},
+		{"norm", normDensity, func(x float64) float64 { return 0.5 * math.Erfc(-x/math.Sqrt2) }},
+		{"half-Cauchy", halfCauchyDensity, func(x float64) float64 { return 2 / math.Pi * math.Atan(x) }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			z := NewZiggurat(New(uint64(testSeeds[0])), test.density)
+			samples := make([]float64, n)
+			for i := range samples {
+				samples[i] = z.Float64()
+			}
+			checkCDF(t, samples, test.cdf)
+		})
+	}
+}
+
+func TestZiggurat_NilRand(t *testing.T) {
+	z := NewZiggurat(nil, halfCauchyDensity)
+	for i := 0; i < 10000; i++ {
+		if x := z.Float64(); !(x >= 0) || math.IsInf(x, 1) {
+			t.Fatalf("got %v outside of [0, +Inf)", x)
+		}
+	}
+}